@@ -0,0 +1,54 @@
+package gdq
+
+type adResult struct {
+	ID       uint     `json:"id"`
+	Order    int      `json:"order"`
+	Suborder int      `json:"suborder"`
+	Sponsor  string   `json:"sponsor_name"`
+	Name     string   `json:"ad_name"`
+	Type     string   `json:"ad_type"`
+	Blurb    string   `json:"blurb"`
+	Length   Duration `json:"length"`
+	Tags     []string `json:"tags"`
+}
+
+type adResp []adResult
+
+func (r adResp) toAds() []*Ad {
+	lad := len(r)
+	if lad == 0 {
+		return nil
+	}
+
+	ads := make([]*Ad, 0, lad)
+	for _, r := range r {
+		ads = append(ads, &Ad{
+			ID:       r.ID,
+			Sponsor:  r.Sponsor,
+			Name:     r.Name,
+			Type:     r.Type,
+			Blurb:    r.Blurb,
+			Length:   r.Length,
+			Order:    r.Order,
+			Suborder: r.Suborder,
+			Tags:     r.Tags,
+		})
+	}
+	return ads
+}
+
+// Ad represents an ad break at a GDQ.
+//
+// Like an [Interview], an ad airs after the run with the same Order, in
+// Suborder order.
+type Ad struct {
+	ID       uint     `json:"id"`
+	Sponsor  string   `json:"sponsor"`
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Blurb    string   `json:"blurb"`
+	Length   Duration `json:"length"`
+	Order    int      `json:"order"`
+	Suborder int      `json:"suborder"`
+	Tags     []string `json:"tags"`
+}
@@ -0,0 +1,138 @@
+package gdq
+
+type bidResp []bidResult
+
+type bidResult struct {
+	ID               uint        `json:"id"`
+	Name             string      `json:"name"`
+	Speedrun         uint        `json:"speedrun"`
+	State            BidState    `json:"state"`
+	Description      string      `json:"description"`
+	ShortDescription string      `json:"shortdescription"`
+	Goal             Money       `json:"goal"`
+	Total            Money       `json:"total"`
+	Count            uint64      `json:"count"`
+	Target           bool        `json:"istarget"`
+	AllowUserOptions bool        `json:"allowuseroptions"`
+	Options          []bidResult `json:"options"`
+}
+
+func (r bidResult) toBid() *Bid {
+	b := &Bid{
+		ID:               r.ID,
+		Name:             r.Name,
+		RunID:            r.Speedrun,
+		State:            r.State,
+		Description:      r.Description,
+		ShortDescription: r.ShortDescription,
+		Goal:             r.Goal,
+		Total:            r.Total,
+		Count:            r.Count,
+		Target:           r.Target,
+		AllowUserOptions: r.AllowUserOptions,
+	}
+	for _, o := range r.Options {
+		opt := o.toBid()
+		if opt.RunID == 0 {
+			opt.RunID = b.RunID
+		}
+		b.Options = append(b.Options, opt)
+	}
+	return b
+}
+
+func (r bidResp) toBids() []*Bid {
+	lbid := len(r)
+	if lbid == 0 {
+		return nil
+	}
+
+	bids := make([]*Bid, 0, lbid)
+	for _, r := range r {
+		bids = append(bids, r.toBid())
+	}
+	return bids
+}
+
+// BidState is the state a [Bid] is in.
+type BidState string
+
+const (
+	BidOpened  BidState = "OPENED"
+	BidClosed  BidState = "CLOSED"
+	BidHidden  BidState = "HIDDEN"
+	BidPending BidState = "PENDING"
+	BidDenied  BidState = "DENIED"
+	BidFlagged BidState = "FLAGGED"
+)
+
+// Bid represents a donation incentive or a bid war at a GDQ.
+//
+// An incentive is a bid with a Goal that donations go towards. A bid war is
+// a bid with Options that donations are split between, in which case the
+// Total is the sum of all its options. Options are bids too and can only be
+// donated to if they're not a bid war themselves.
+//
+// RunID is the ID of the [Run] the bid is attached to, or 0 if the bid
+// isn't tied to a specific run.
+type Bid struct {
+	ID               uint     `json:"id"`
+	Name             string   `json:"name"`
+	RunID            uint     `json:"run_id"`
+	State            BidState `json:"state"`
+	Description      string   `json:"description"`
+	ShortDescription string   `json:"short_description"`
+	Goal             Money    `json:"goal"`
+	Total            Money    `json:"total"`
+	Count            uint64   `json:"count"`
+	Target           bool     `json:"target"`
+	AllowUserOptions bool     `json:"allow_user_options"`
+	Options          []*Bid   `json:"options,omitempty"`
+}
+
+// IsBidWar returns whether donations are split between multiple options.
+func (b *Bid) IsBidWar() bool {
+	return len(b.Options) > 0 || b.AllowUserOptions
+}
+
+// Leader returns the option with the highest total in a bid war.
+//
+// It returns nil if the bid has no options. Options with a total in a
+// different currency than the first option are ignored.
+func (b *Bid) Leader() *Bid {
+	var leader *Bid
+	for _, o := range b.Options {
+		if leader == nil {
+			leader = o
+			continue
+		}
+		if c, err := o.Total.Cmp(leader.Total); err == nil && c > 0 {
+			leader = o
+		}
+	}
+	return leader
+}
+
+// Remaining returns the amount still needed to reach the goal of an
+// incentive.
+//
+// It returns 0 once the goal has been reached, if the bid has no goal, or
+// if the goal and the total are in different currencies.
+func (b *Bid) Remaining() Money {
+	rem, err := b.Goal.Sub(b.Total)
+	if err != nil || rem.Cents() <= 0 {
+		return NewMoney(0, b.Goal.Currency())
+	}
+	return rem
+}
+
+// BidsForRun returns the bids attached to the run.
+func BidsForRun(bids []*Bid, run *Run) []*Bid {
+	var res []*Bid
+	for _, b := range bids {
+		if run != nil && b.RunID != 0 && b.RunID == run.ID {
+			res = append(res, b)
+		}
+	}
+	return res
+}
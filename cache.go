@@ -0,0 +1,272 @@
+package gdq
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheStats reports how effective the response cache of a [Client] is.
+//
+// Hits counts the requests served from the cache without contacting the
+// tracker. Revalidations counts the requests for which the tracker reported
+// that the cached response was still current. Misses counts the requests
+// for which a full response had to be retrieved. WriteErrors counts the
+// responses that couldn't be written to the disk cache, which are still
+// cached in memory.
+type CacheStats struct {
+	Hits          uint64 `json:"hits"`
+	Revalidations uint64 `json:"revalidations"`
+	Misses        uint64 `json:"misses"`
+	WriteErrors   uint64 `json:"write_errors"`
+}
+
+// DefaultCacheDir returns the directory responses are cached in by default,
+// which is a gdq directory in the user's cache directory.
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gdq"), nil
+}
+
+type cacheEntry struct {
+	URL          string    `json:"url"`
+	Body         []byte    `json:"body"`
+	ETag         string    `json:"etag"`
+	LastModified string    `json:"last_modified"`
+	Fetched      time.Time `json:"fetched"`
+}
+
+// validators returns the headers to make a conditional request for the
+// entry.
+func (e *cacheEntry) validators() http.Header {
+	h := http.Header{}
+	if e == nil {
+		return h
+	}
+	if e.ETag != "" {
+		h.Set("If-None-Match", e.ETag)
+	}
+	if e.LastModified != "" {
+		h.Set("If-Modified-Since", e.LastModified)
+	}
+	return h
+}
+
+// responseCache is a cache of tracker responses, keyed on the URL.
+//
+// Entries are kept in memory. When dir is set they're also written to disk,
+// so they survive restarts.
+type responseCache struct {
+	l       sync.Mutex
+	entries map[string]*cacheEntry
+	ttl     time.Duration
+	ttls    map[string]time.Duration
+	dir     string
+	stats   CacheStats
+}
+
+func newResponseCache() *responseCache {
+	return &responseCache{
+		entries: map[string]*cacheEntry{},
+		ttls:    map[string]time.Duration{},
+	}
+}
+
+// lookup returns the entry for the endpoint, if there is one, and whether
+// it's fresh enough to be used without asking the tracker.
+func (rc *responseCache) lookup(endpoint string, now time.Time) (*cacheEntry, bool) {
+	rc.l.Lock()
+	defer rc.l.Unlock()
+
+	e := rc.entry(endpoint)
+	if e == nil {
+		return nil, false
+	}
+
+	ttl, ok := rc.ttls[resource(endpoint)]
+	if !ok {
+		ttl = rc.ttl
+	}
+	if now.Sub(e.Fetched) < ttl {
+		rc.stats.Hits++
+		return e, true
+	}
+	return e, false
+}
+
+// lookupStale returns the entry for the endpoint, as long as it isn't
+// older than maxAge. A maxAge of 0 means any entry will do.
+func (rc *responseCache) lookupStale(endpoint string, now time.Time, maxAge time.Duration) (*cacheEntry, bool) {
+	rc.l.Lock()
+	defer rc.l.Unlock()
+
+	e := rc.entry(endpoint)
+	if e == nil {
+		return nil, false
+	}
+	if maxAge > 0 && now.Sub(e.Fetched) > maxAge {
+		return nil, false
+	}
+	rc.stats.Hits++
+	return e, true
+}
+
+// entry returns the entry for the endpoint, loading it from disk if it
+// isn't in memory. The caller must hold the lock.
+func (rc *responseCache) entry(endpoint string) *cacheEntry {
+	if e, ok := rc.entries[endpoint]; ok {
+		return e
+	}
+	if rc.dir == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(rc.path(endpoint))
+	if err != nil {
+		return nil
+	}
+	var e cacheEntry
+	if err := json.Unmarshal(data, &e); err != nil || e.URL != endpoint {
+		return nil
+	}
+	rc.entries[endpoint] = &e
+	return &e
+}
+
+// persist writes a copy of the entry to disk, if the cache has a directory.
+// The caller must not hold the lock. Failing to write isn't fatal, since
+// the entry is still cached in memory, but it's counted in the stats.
+func (rc *responseCache) persist(e cacheEntry) {
+	if rc.dir == "" {
+		return
+	}
+	if err := rc.write(e); err != nil {
+		rc.l.Lock()
+		rc.stats.WriteErrors++
+		rc.l.Unlock()
+	}
+}
+
+func (rc *responseCache) write(e cacheEntry) error {
+	if err := os.MkdirAll(rc.dir, 0o700); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(rc.dir, "*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), rc.path(e.URL))
+}
+
+func (rc *responseCache) path(endpoint string) string {
+	sum := sha256.Sum256([]byte(endpoint))
+	return filepath.Join(rc.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// revalidated marks the entry as current as of now.
+func (rc *responseCache) revalidated(endpoint string, now time.Time) {
+	rc.l.Lock()
+	rc.stats.Revalidations++
+	e := rc.entry(endpoint)
+	if e == nil {
+		rc.l.Unlock()
+		return
+	}
+	e.Fetched = now
+	cp := *e
+	rc.l.Unlock()
+
+	rc.persist(cp)
+}
+
+// store caches the response for the endpoint.
+func (rc *responseCache) store(endpoint string, resp *response, now time.Time) {
+	e := cacheEntry{
+		URL:          endpoint,
+		Body:         resp.body,
+		ETag:         resp.header.Get("ETag"),
+		LastModified: resp.header.Get("Last-Modified"),
+		Fetched:      now,
+	}
+	cp := e
+
+	rc.l.Lock()
+	rc.stats.Misses++
+	rc.entries[endpoint] = &e
+	rc.l.Unlock()
+
+	rc.persist(cp)
+}
+
+func (rc *responseCache) snapshot() CacheStats {
+	rc.l.Lock()
+	defer rc.l.Unlock()
+	return rc.stats
+}
+
+// uncached are the resources that are never cached. Donations are paginated
+// and keep coming in, so caching them would keep every page of every event
+// in memory for as long as the client lives.
+var uncached = map[string]bool{
+	"donations": true,
+}
+
+// cacheable returns whether responses for the endpoint can be cached.
+func cacheable(endpoint string) bool {
+	return !uncached[resource(endpoint)]
+}
+
+// resource returns the kind of data an endpoint returns, like "events" or
+// "runs".
+//
+// This is the path segment following the last ID in the path, or the one
+// preceding it if the ID is the last segment. For paths without an ID it's
+// the last segment.
+func resource(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return ""
+	}
+
+	segs := strings.FieldsFunc(u.Path, func(r rune) bool { return r == '/' })
+	if len(segs) == 0 {
+		return ""
+	}
+	for i := len(segs) - 1; i >= 0; i-- {
+		if _, err := strconv.ParseUint(segs[i], 10, 64); err != nil {
+			continue
+		}
+		if i+1 < len(segs) {
+			return segs[i+1]
+		}
+		if i > 0 {
+			return segs[i-1]
+		}
+		return ""
+	}
+	return segs[len(segs)-1]
+}
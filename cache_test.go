@@ -0,0 +1,199 @@
+package gdq
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestResource(t *testing.T) {
+	for in, out := range map[string]string{
+		"https://gamesdonequick.com/tracker/api/v2/events/":                "events",
+		"https://gamesdonequick.com/tracker/api/v2/events/34/?totals=true": "events",
+		"https://gamesdonequick.com/tracker/api/v2/events/34/runs/":        "runs",
+		"https://gamesdonequick.com/tracker/api/v2/events/34/bids/tree/":   "bids",
+		"https://gamesdonequick.com/tracker/api/v2/talent/884/":            "talent",
+		"http://localhost/events/34/donations/?page=2":                     "donations",
+		"http://localhost/34/": "",
+		"http://localhost/":    "",
+	} {
+		assert.Equal(t, out, resource(in), in)
+	}
+}
+
+func TestClientCache(t *testing.T) {
+	var calls, conditional atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			conditional.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `hello`)
+	}))
+	defer ts.Close()
+
+	t.Run("disabled", func(t *testing.T) {
+		calls.Store(0)
+		c := New()
+		for range 2 {
+			_, err := c.get(context.Background(), ts.URL+"/events/")
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, 2, calls.Load())
+		assert.Equal(t, CacheStats{}, c.CacheStats())
+	})
+	t.Run("fresh", func(t *testing.T) {
+		calls.Store(0)
+		c := New(WithCache(time.Hour))
+		for range 3 {
+			data, err := c.get(context.Background(), ts.URL+"/events/")
+			assert.NoError(t, err)
+			assert.Equal(t, "hello", string(data))
+		}
+		assert.Equal(t, 1, calls.Load())
+		assert.Equal(t, CacheStats{Hits: 2, Misses: 1}, c.CacheStats())
+	})
+	t.Run("revalidated", func(t *testing.T) {
+		calls.Store(0)
+		conditional.Store(0)
+		c := New(WithCache(0))
+		for range 3 {
+			data, err := c.get(context.Background(), ts.URL+"/events/")
+			assert.NoError(t, err)
+			assert.Equal(t, "hello", string(data))
+		}
+		assert.Equal(t, 3, calls.Load())
+		assert.Equal(t, 2, conditional.Load())
+		assert.Equal(t, CacheStats{Revalidations: 2, Misses: 1}, c.CacheStats())
+	})
+	t.Run("per resource ttl", func(t *testing.T) {
+		calls.Store(0)
+		c := New(WithCache(time.Hour), WithCacheTTL("runs", 0))
+		for range 2 {
+			_, err := c.get(context.Background(), ts.URL+"/events/34/runs/")
+			assert.NoError(t, err)
+			_, err = c.get(context.Background(), ts.URL+"/events/")
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, 3, calls.Load())
+		assert.Equal(t, CacheStats{Hits: 1, Revalidations: 1, Misses: 2}, c.CacheStats())
+	})
+	t.Run("donations are not cached", func(t *testing.T) {
+		calls.Store(0)
+		c := New(WithCache(time.Hour))
+		for range 2 {
+			_, err := c.get(context.Background(), ts.URL+"/events/34/donations/?page=2")
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, 2, calls.Load())
+		assert.Equal(t, CacheStats{}, c.CacheStats())
+	})
+	t.Run("tracker down", func(t *testing.T) {
+		var down atomic.Bool
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if down.Load() {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			fmt.Fprint(w, `hello`)
+		}))
+		defer ts.Close()
+
+		c := New(WithCache(0))
+		_, err := c.get(context.Background(), ts.URL+"/events/")
+		assert.NoError(t, err)
+		down.Store(true)
+		data, err := c.get(context.Background(), ts.URL+"/events/")
+		assert.NoError(t, err)
+		assert.Equal(t, "hello", string(data))
+
+		_, err = c.get(context.Background(), ts.URL+"/events/34/runs/")
+		assert.IsError(t, err, ErrServer)
+
+		c = New(WithCache(0), WithMaxStale(time.Nanosecond))
+		down.Store(false)
+		_, err = c.get(context.Background(), ts.URL+"/events/")
+		assert.NoError(t, err)
+		down.Store(true)
+		time.Sleep(time.Millisecond)
+		_, err = c.get(context.Background(), ts.URL+"/events/")
+		assert.IsError(t, err, ErrServer)
+	})
+	t.Run("errors are not cached", func(t *testing.T) {
+		ts := httptest.NewServer(http.NotFoundHandler())
+		defer ts.Close()
+		c := New(WithCache(time.Hour))
+		for range 2 {
+			_, err := c.get(context.Background(), ts.URL)
+			assert.IsError(t, err, ErrNotFound)
+		}
+		assert.Equal(t, CacheStats{}, c.CacheStats())
+	})
+}
+
+func TestClientDiskCache(t *testing.T) {
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `hello`)
+	}))
+	defer ts.Close()
+	dir := t.TempDir()
+
+	c := New(WithDiskCache(dir), WithCache(time.Hour))
+	_, err := c.get(context.Background(), ts.URL+"/events/")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls.Load())
+
+	t.Run("survives a new client", func(t *testing.T) {
+		c := New(WithDiskCache(dir), WithCache(time.Hour))
+		data, err := c.get(context.Background(), ts.URL+"/events/")
+		assert.NoError(t, err)
+		assert.Equal(t, "hello", string(data))
+		assert.Equal(t, 1, calls.Load())
+		assert.Equal(t, CacheStats{Hits: 1}, c.CacheStats())
+	})
+	t.Run("offline", func(t *testing.T) {
+		c := New(WithDiskCache(dir), WithOffline(0))
+		data, err := c.get(context.Background(), ts.URL+"/events/")
+		assert.NoError(t, err)
+		assert.Equal(t, "hello", string(data))
+		assert.Equal(t, 1, calls.Load())
+	})
+	t.Run("offline and not cached", func(t *testing.T) {
+		c := New(WithDiskCache(dir), WithOffline(0))
+		_, err := c.get(context.Background(), ts.URL+"/events/34/runs/")
+		assert.IsError(t, err, ErrNotCached)
+		assert.Equal(t, 1, calls.Load())
+	})
+	t.Run("offline and too old", func(t *testing.T) {
+		c := New(WithDiskCache(dir), WithOffline(time.Nanosecond))
+		time.Sleep(time.Millisecond)
+		_, err := c.get(context.Background(), ts.URL+"/events/")
+		assert.IsError(t, err, ErrNotCached)
+		assert.Equal(t, 1, calls.Load())
+	})
+	t.Run("write errors", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "file")
+		assert.NoError(t, os.WriteFile(file, nil, 0o600))
+		c := New(WithDiskCache(filepath.Join(file, "cache")), WithCache(time.Hour))
+		for range 2 {
+			data, err := c.get(context.Background(), ts.URL+"/events/")
+			assert.NoError(t, err)
+			assert.Equal(t, "hello", string(data))
+		}
+		assert.Equal(t, CacheStats{Hits: 1, Misses: 1, WriteErrors: 1}, c.CacheStats())
+	})
+}
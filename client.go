@@ -1,11 +1,15 @@
 package gdq
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"iter"
 	"net/http"
+	"slices"
 	"time"
 )
 
@@ -15,37 +19,52 @@ const (
 
 // Client is a GDQ API client.
 type Client struct {
-	c  *http.Client
-	v2 string
+	c         *http.Client
+	baseURL   string
+	timeout   time.Duration
+	userAgent string
+	retry     RetryPolicy
+	cache     *responseCache
+	offline   bool
+	maxAge    time.Duration
 }
 
 // New creates a new GDQ API client.
-func New(client *http.Client) *Client {
-	return &Client{
-		c:  client,
-		v2: trackerV2,
+//
+// Without any options it talks to the GDQ tracker using
+// [http.DefaultClient].
+func New(opts ...Option) *Client {
+	c := &Client{
+		c:       http.DefaultClient,
+		baseURL: trackerV2,
+		timeout: 1 * time.Minute,
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
 }
 
 // Events returns all events, sorted by start date.
 func (c *Client) Events(ctx context.Context) ([]*Event, error) {
-	resp, err := fromJSON[eventsResp](ctx, c.c, fmt.Sprintf("%s/events/", c.v2))
+	resp, err := fetchAll[eventResp](ctx, c, fmt.Sprintf("%s/events/", c.baseURL))
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve events: %w", err)
 	}
 
-	if len(resp.Results) == 0 {
-		return nil, fmt.Errorf("there are no known events")
+	if len(resp) == 0 {
+		return nil, ErrNoEvents
 	}
 
-	return resp.toEvents(), nil
+	return eventsResp(resp).toEvents(), nil
 }
 
 // Event retrieves event information for the event ID.
 //
-// Contrary to [Client.Events], this includes donation information.
+// Contrary to [Client.Events], this includes donation information. It
+// doesn't include the end of the event, use [Client.EventEnd] for that.
 func (c *Client) Event(ctx context.Context, ev uint) (*Event, error) {
-	resp, err := fromJSON[eventResp](ctx, c.c, fmt.Sprintf("%s/events/%d/?totals=true", c.v2, ev))
+	resp, err := fromJSON[eventResp](ctx, c, fmt.Sprintf("%s/events/%d/?totals=true", c.baseURL, ev))
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve data for event %d: %w", ev, err)
 	}
@@ -53,23 +72,200 @@ func (c *Client) Event(ctx context.Context, ev uint) (*Event, error) {
 	return resp.toEvent(), nil
 }
 
+// EventEnd returns the end of the last run of the event.
+//
+// The tracker doesn't know when an event ends, so this retrieves all the
+// runs of the event. If you already have its [Schedule], use
+// [Schedule.End] instead.
+func (c *Client) EventEnd(ctx context.Context, ev uint) (time.Time, error) {
+	runs, err := c.Runs(ctx, ev)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return NewScheduleFrom(runs).End(), nil
+}
+
+// CurrentEvent returns the event that's currently live. If there isn't one
+// it returns the next upcoming event, and failing that the event that
+// started most recently.
+//
+// Like [Client.Event], this includes donation information. Since whether an
+// event is live depends on when it ends, it also retrieves the runs of the
+// most recently started event and includes its end. The end of an upcoming
+// event isn't included.
+func (c *Client) CurrentEvent(ctx context.Context) (*Event, error) {
+	evs, err := c.Events(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	evs = slices.DeleteFunc(slices.Clone(evs), func(e *Event) bool { return e.Start.IsZero() })
+	slices.SortStableFunc(evs, func(a, b *Event) int {
+		return cmp.Or(a.Start.Compare(b.Start), cmp.Compare(a.ID, b.ID))
+	})
+
+	now := time.Now()
+	next := slices.IndexFunc(evs, func(e *Event) bool { return e.Start.After(now) })
+	if next == -1 {
+		next = len(evs)
+	}
+
+	var recent *Event
+	if next > 0 {
+		recent, err = c.Event(ctx, evs[next-1].ID)
+		if err != nil {
+			return nil, err
+		}
+		recent.End, err = c.EventEnd(ctx, recent.ID)
+		if err != nil && !errors.Is(err, ErrNoRuns) {
+			return nil, err
+		}
+		if recent.Status(now) == StatusLive {
+			return recent, nil
+		}
+	}
+	if next < len(evs) {
+		return c.Event(ctx, evs[next].ID)
+	}
+	if recent == nil {
+		return nil, ErrNoEvents
+	}
+	return recent, nil
+}
+
+// Talent retrieves the profile of a runner, host or commentator by their ID.
+func (c *Client) Talent(ctx context.Context, id uint) (*Talent, error) {
+	resp, err := fromJSON[Talent](ctx, c, fmt.Sprintf("%s/talent/%d/", c.baseURL, id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve talent %d: %w", id, err)
+	}
+
+	return resp, nil
+}
+
 // Runs returns all runs for an Event.
 func (c *Client) Runs(ctx context.Context, ev uint) ([]*Run, error) {
-	resp, err := fromJSON[runResp](ctx, c.c, fmt.Sprintf("%s/events/%d/runs/", c.v2, ev))
+	resp, err := fetchAll[runResult](ctx, c, fmt.Sprintf("%s/events/%d/runs/", c.baseURL, ev))
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve runs for event %d: %w", ev, err)
 	}
 
-	if len(resp.Results) == 0 {
-		return nil, fmt.Errorf("there are no interviews")
+	if len(resp) == 0 {
+		return nil, ErrNoRuns
+	}
+
+	return runResp(resp).toRuns(), nil
+}
+
+// Interviews returns all interviews for an Event.
+func (c *Client) Interviews(ctx context.Context, ev uint) ([]*Interview, error) {
+	resp, err := fetchAll[interviewResult](ctx, c, fmt.Sprintf("%s/events/%d/interviews/", c.baseURL, ev))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve interviews for event %d: %w", ev, err)
+	}
+
+	if len(resp) == 0 {
+		return nil, ErrNoInterviews
+	}
+
+	return interviewResp(resp).toInterviews(), nil
+}
+
+// Ads returns all ads for an Event.
+//
+// The tracker only shows ads to authenticated users, so this typically fails.
+func (c *Client) Ads(ctx context.Context, ev uint) ([]*Ad, error) {
+	resp, err := fetchAll[adResult](ctx, c, fmt.Sprintf("%s/events/%d/ads/", c.baseURL, ev))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve ads for event %d: %w", ev, err)
+	}
+
+	if len(resp) == 0 {
+		return nil, ErrNoAds
+	}
+
+	return adResp(resp).toAds(), nil
+}
+
+// Bids returns all bids for an Event.
+//
+// Only the top-level bids are returned. The options of a bid war are
+// available through [Bid.Options].
+func (c *Client) Bids(ctx context.Context, ev uint) ([]*Bid, error) {
+	resp, err := fetchAll[bidResult](ctx, c, fmt.Sprintf("%s/events/%d/bids/tree/", c.baseURL, ev))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve bids for event %d: %w", ev, err)
+	}
+
+	if len(resp) == 0 {
+		return nil, ErrNoBids
+	}
+
+	return bidResp(resp).toBids(), nil
+}
+
+// Milestones returns all donation milestones for an Event.
+//
+// Use [NextMilestone] together with the donation total from [Client.Event]
+// to figure out which milestone is up next.
+func (c *Client) Milestones(ctx context.Context, ev uint) ([]*Milestone, error) {
+	resp, err := fetchAll[milestoneResult](ctx, c, fmt.Sprintf("%s/events/%d/milestones/", c.baseURL, ev))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve milestones for event %d: %w", ev, err)
+	}
+
+	if len(resp) == 0 {
+		return nil, ErrNoMilestones
+	}
+
+	return milestoneResp(resp).toMilestones(), nil
+}
+
+// Prizes returns all prizes for an Event.
+//
+// Use [AvailablePrizes] together with the [Schedule] for the event to find
+// the prizes that are currently open for entry.
+func (c *Client) Prizes(ctx context.Context, ev uint) ([]*Prize, error) {
+	resp, err := fetchAll[prizeResult](ctx, c, fmt.Sprintf("%s/events/%d/prizes/", c.baseURL, ev))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve prizes for event %d: %w", ev, err)
+	}
+
+	if len(resp) == 0 {
+		return nil, ErrNoPrizes
 	}
 
-	return resp.toRuns(), nil
+	return prizeResp(resp).toPrizes(), nil
+}
+
+// Donations returns an iterator over all donations for an Event.
+//
+// Donations are retrieved a page at a time as the iterator advances, so you
+// can stop early without retrieving all of them. If retrieving a page fails
+// the error is yielded and iteration stops.
+//
+// Since donations keep coming in while you iterate, the number of donations
+// isn't checked against the count the tracker reports.
+func (c *Client) Donations(ctx context.Context, ev uint) iter.Seq2[*DonationEntry, error] {
+	return func(yield func(*DonationEntry, error) bool) {
+		for p, err := range pages[donationResult](ctx, c, fmt.Sprintf("%s/events/%d/donations/", c.baseURL, ev)) {
+			if err != nil {
+				yield(nil, fmt.Errorf("failed to retrieve donations for event %d: %w", ev, err))
+				return
+			}
+			for _, r := range p.Results {
+				if !yield(r.toDonationEntry(), nil) {
+					return
+				}
+			}
+		}
+	}
 }
 
 // Schedule returns the [Schedule] for a GDQ event.
 //
-// This schedule only contains runs, not interviews.
+// This schedule only contains runs, not interviews. Use [Client.Timeline] if
+// you need those too.
 func (c *Client) Schedule(ctx context.Context, ev uint) (*Schedule, error) {
 	runs, err := c.Runs(ctx, ev)
 	if err != nil {
@@ -78,48 +274,177 @@ func (c *Client) Schedule(ctx context.Context, ev uint) (*Schedule, error) {
 	return NewScheduleFrom(runs), nil
 }
 
-func getWithCtx(ctx context.Context, c *http.Client, url string) ([]byte, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+// Timeline returns the [Timeline] for a GDQ event.
+//
+// Ads are included when the tracker is willing to hand them out. When it
+// refuses to, the timeline only contains runs and interviews.
+func (c *Client) Timeline(ctx context.Context, ev uint) (*Timeline, error) {
+	runs, err := c.Runs(ctx, ev)
+	if err != nil {
+		return nil, err
+	}
+
+	ivs, err := fetchAll[interviewResult](ctx, c, fmt.Sprintf("%s/events/%d/interviews/", c.baseURL, ev))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve interviews for event %d: %w", ev, err)
+	}
+
+	ads, err := fetchAll[adResult](ctx, c, fmt.Sprintf("%s/events/%d/ads/", c.baseURL, ev))
+	if err != nil && !errors.Is(err, ErrForbidden) {
+		return nil, fmt.Errorf("failed to retrieve ads for event %d: %w", ev, err)
+	}
+
+	return NewTimeline(runs, interviewResp(ivs).toInterviews(), adResp(ads).toAds()), nil
+}
+
+// CacheStats returns statistics about the response cache.
+//
+// The statistics are all zero unless the cache has been enabled with
+// [WithCache].
+func (c *Client) CacheStats() CacheStats {
+	if c.cache == nil {
+		return CacheStats{}
+	}
+	return c.cache.snapshot()
+}
+
+// response is the part of an HTTP response the client holds on to.
+type response struct {
+	status int
+	body   []byte
+	header http.Header
+}
+
+// getWithCtx makes a GET request to url, with the additional headers.
+func (c *Client) getWithCtx(ctx context.Context, url string, header http.Header) (*response, error) {
+	return c.do(ctx, http.MethodGet, url, header)
+}
+
+// do makes a request to url, with the additional headers.
+//
+// Any response other than a 200 OK or a 304 Not Modified is returned as an
+// [APIError].
+func (c *Client) do(ctx context.Context, method string, url string, header http.Header) (*response, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
 	if err != nil {
 		return nil, err
 	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
 	req.Header.Set("Accept", "application/json")
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
 
-	resp, err := c.Do(req)
+	resp, err := c.c.Do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
 	switch resp.StatusCode {
-	case http.StatusOK:
-		return io.ReadAll(resp.Body)
-	case http.StatusBadRequest, http.StatusNotFound:
-		msg, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, fmt.Errorf("client error: %w", err)
+	case http.StatusOK, http.StatusNotModified:
+		return &response{status: resp.StatusCode, body: body, header: resp.Header}, nil
+	}
+
+	aerr := &APIError{
+		StatusCode: resp.StatusCode,
+		Endpoint:   url,
+		Body:       body,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+	}
+	cerr := struct {
+		Detail string `json:"detail"`
+	}{}
+	if err := json.Unmarshal(body, &cerr); err == nil {
+		aerr.Detail = cerr.Detail
+	}
+	return nil, aerr
+}
+
+// get retrieves the endpoint, from the cache if possible.
+func (c *Client) get(ctx context.Context, endpoint string) ([]byte, error) {
+	cache := c.cache
+	if !cacheable(endpoint) {
+		cache = nil
+	}
+
+	if c.offline {
+		if cache == nil {
+			return nil, ErrNotCached
 		}
-		cerr := struct {
-			Detail string `json:"detail"`
-		}{}
-		err = json.Unmarshal(msg, &cerr)
-		if err != nil {
-			return nil, fmt.Errorf("client error, failed to unmarshal body: %w", err)
+		entry, ok := cache.lookupStale(endpoint, time.Now(), c.maxAge)
+		if !ok {
+			return nil, ErrNotCached
 		}
-		if cerr.Detail == "" {
-			return nil, fmt.Errorf("client error, unexpected body: %s", string(msg))
+		return entry.Body, nil
+	}
+
+	var entry *cacheEntry
+	if cache != nil {
+		var fresh bool
+		entry, fresh = cache.lookup(endpoint, time.Now())
+		if fresh {
+			return entry.Body, nil
+		}
+	}
+
+	resp, err := c.retrying(ctx, http.MethodGet, endpoint, entry.validators())
+	if err != nil {
+		// When the tracker is down, the last known response beats an error.
+		if cache != nil && retryable(ctx, http.MethodGet, err) {
+			if entry, ok := cache.lookupStale(endpoint, time.Now(), c.maxAge); ok {
+				return entry.Body, nil
+			}
+		}
+		return nil, err
+	}
+
+	if resp.status == http.StatusNotModified {
+		if entry == nil {
+			return nil, &APIError{StatusCode: resp.status, Endpoint: endpoint, Body: resp.body}
 		}
-		return nil, fmt.Errorf("client error: %s ", cerr.Detail)
-	default:
-		return nil, fmt.Errorf("received unexpected status code: %s", resp.Status)
+		cache.revalidated(endpoint, time.Now())
+		return entry.Body, nil
+	}
+
+	if cache != nil {
+		cache.store(endpoint, resp, time.Now())
 	}
+	return resp.body, nil
 }
 
-func fromJSON[T any](ctx context.Context, c *http.Client, endpoint string) (*T, error) {
-	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
-	defer cancel()
+// retrying makes a request to the endpoint, retrying according to the
+// client's [RetryPolicy].
+func (c *Client) retrying(ctx context.Context, method string, endpoint string, header http.Header) (*response, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := c.do(ctx, method, endpoint, header)
+		if err == nil {
+			return resp, nil
+		}
+		if attempt >= c.retry.MaxAttempts || !retryable(ctx, method, err) {
+			return nil, err
+		}
+		if serr := sleep(ctx, c.retry.delay(attempt, err)); serr != nil {
+			return nil, err
+		}
+	}
+}
 
-	data, err := getWithCtx(ctx, c, endpoint)
+func fromJSON[T any](ctx context.Context, c *Client, endpoint string) (*T, error) {
+	data, err := c.get(ctx, endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve data from: %s, %w", endpoint, err)
 	}
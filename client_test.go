@@ -4,11 +4,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/alecthomas/assert/v2"
 )
@@ -16,48 +19,66 @@ import (
 func newTestMux(t *testing.T) *http.ServeMux {
 	t.Helper()
 
-	var runBuf bytes.Buffer
-	runData, err := os.ReadFile("testdata/runs-34.json")
-	if err != nil {
-		t.Fatalf("got error reading data: %s", err)
-	}
-	if err := json.Compact(&runBuf, runData); err != nil {
-		t.Fatalf("data is not valid JSON: %s", err)
-	}
+	mux := http.NewServeMux()
+	serveTestdata(t, mux, "/events/34/runs/", "testdata/runs-34.json")
+	serveTestdata(t, mux, "/events/34/interviews/", "testdata/interviews-34.json")
+	serveTestdata(t, mux, "/events/34/bids/tree/", "testdata/bids-34.json")
+	serveTestdata(t, mux, "/events/34/milestones/", "testdata/milestones-34.json")
+	serveTestdata(t, mux, "/events/34/prizes/", "testdata/prizes-34.json")
+	mux.HandleFunc("/talent/884/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"type":"talent","id":884,"name":"Kungfufruitcup","stream":"https://twitch.tv/kungfufruitcup","twitter":"kungfufruitcup","youtube":"kungfufruitcup","platform":"TWITCH","pronouns":"she/her"}`)
+	})
+	mux.HandleFunc("/events/34/ads/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"detail":"You do not have permission to perform this action."}`)
+	})
+	return mux
+}
+
+func serveTestdata(t *testing.T, mux *http.ServeMux, pattern string, file string) {
+	t.Helper()
 
-	var ivBuf bytes.Buffer
-	ivData, err := os.ReadFile("testdata/interviews-34.json")
+	var buf bytes.Buffer
+	data, err := os.ReadFile(file)
 	if err != nil {
 		t.Fatalf("got error reading data: %s", err)
 	}
-	if err := json.Compact(&ivBuf, ivData); err != nil {
+	if err := json.Compact(&buf, data); err != nil {
 		t.Fatalf("data is not valid JSON: %s", err)
 	}
 
-	mux := http.NewServeMux()
-	mux.HandleFunc("/events/34/runs/", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(200)
-		w.Write(runBuf.Bytes())
-	})
-	mux.HandleFunc("/events/34/interviews/", func(w http.ResponseWriter, r *http.Request) {
+	mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(200)
-		w.Write(ivBuf.Bytes())
+		w.Write(buf.Bytes())
 	})
-	return mux
 }
 
 func TestGetSchedule(t *testing.T) {
 	ts := httptest.NewServer(newTestMux(t))
 	defer ts.Close()
 
-	c := New(http.DefaultClient)
-	c.v2 = fmt.Sprintf("http://%s", ts.Listener.Addr().String())
+	c := New(WithBaseURL(ts.URL))
 
 	s, err := c.Schedule(context.TODO(), AGDQ2021.ID)
 	assert.NoError(t, err)
 	assert.Equal(t, 157, len(s.Runs))
 	assert.Equal(t, 31, len(s.byHost))
 	assert.Equal(t, 162, len(s.byRunner))
+
+	run := s.Runs[1]
+	assert.Equal(t, uint(4662), run.ID)
+	assert.Equal(t, "Mirror's Edge", run.DisplayName)
+	assert.Equal(t, 2009, run.ReleaseYear)
+	assert.Equal(t, LocationOnsite, run.Onsite)
+	assert.False(t, run.Coop)
+	assert.Equal(t, 2, run.Order)
+	assert.Equal(t, 1, len(run.VideoLinks))
+	assert.Equal(t, "youtube", run.VideoLinks[0].Type)
+	assert.Equal(t, "Retro", s.Runs[0].TwitchName)
+	assert.Equal(t, 20*time.Minute, s.Runs[0].RunTime.Duration)
+	assert.Equal(t, 6*time.Minute, s.Runs[0].SetupTime.Duration)
+	assert.Equal(t, s.Runs[1].Start, s.Runs[0].End())
+	assert.True(t, s.Runs[0].AnchorTime.IsZero())
 }
 
 func TestGetWithCtx(t *testing.T) {
@@ -67,9 +88,9 @@ func TestGetWithCtx(t *testing.T) {
 		}))
 		defer ts.Close()
 		ctx := context.Background()
-		resp, err := getWithCtx(ctx, http.DefaultClient, ts.URL)
+		resp, err := New().getWithCtx(ctx, ts.URL, nil)
 		assert.NoError(t, err)
-		assert.Equal(t, "hello", string(resp))
+		assert.Equal(t, "hello", string(resp.body))
 	})
 	t.Run("with bad request", func(t *testing.T) {
 		t.Run("with non-JSON body", func(t *testing.T) {
@@ -79,9 +100,14 @@ func TestGetWithCtx(t *testing.T) {
 			}))
 			defer ts.Close()
 			ctx := context.Background()
-			_, err := getWithCtx(ctx, http.DefaultClient, ts.URL)
-			assert.Error(t, err)
-			assert.Contains(t, err.Error(), "failed to unmarshal")
+			_, err := New().getWithCtx(ctx, ts.URL, nil)
+			var aerr *APIError
+			assert.True(t, errors.As(err, &aerr))
+			assert.Equal(t, http.StatusBadRequest, aerr.StatusCode)
+			assert.Equal(t, ts.URL, aerr.Endpoint)
+			assert.Equal(t, "", aerr.Detail)
+			assert.Equal(t, "hello", string(aerr.Body))
+			assert.IsError(t, err, ErrBadRequest)
 		})
 		t.Run("with unexpected JSON body", func(t *testing.T) {
 			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -90,9 +116,12 @@ func TestGetWithCtx(t *testing.T) {
 			}))
 			defer ts.Close()
 			ctx := context.Background()
-			_, err := getWithCtx(ctx, http.DefaultClient, ts.URL)
-			assert.Error(t, err)
-			assert.Contains(t, err.Error(), "unexpected body")
+			_, err := New().getWithCtx(ctx, ts.URL, nil)
+			var aerr *APIError
+			assert.True(t, errors.As(err, &aerr))
+			assert.Equal(t, "", aerr.Detail)
+			assert.Equal(t, `{"test": true}`, string(aerr.Body))
+			assert.Contains(t, err.Error(), "unexpected status code")
 		})
 		t.Run("with JSON error", func(t *testing.T) {
 			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -101,8 +130,10 @@ func TestGetWithCtx(t *testing.T) {
 			}))
 			defer ts.Close()
 			ctx := context.Background()
-			_, err := getWithCtx(ctx, http.DefaultClient, ts.URL)
-			assert.Error(t, err)
+			_, err := New().getWithCtx(ctx, ts.URL, nil)
+			var aerr *APIError
+			assert.True(t, errors.As(err, &aerr))
+			assert.Equal(t, "Malformed some parameter", aerr.Detail)
 			assert.Contains(t, err.Error(), "Malformed some parameter")
 		})
 	})
@@ -113,9 +144,21 @@ func TestGetWithCtx(t *testing.T) {
 		}))
 		defer ts.Close()
 		ctx := context.Background()
-		_, err := getWithCtx(ctx, http.DefaultClient, ts.URL)
+		_, err := New().getWithCtx(ctx, ts.URL, nil)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "resource does not exist")
+		assert.IsError(t, err, ErrNotFound)
+		assert.NotIsError(t, err, ErrServer)
+	})
+	t.Run("with rate limit", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, `{"detail":"Request was throttled."}`)
+		}))
+		defer ts.Close()
+		ctx := context.Background()
+		_, err := New().getWithCtx(ctx, ts.URL, nil)
+		assert.IsError(t, err, ErrRateLimited)
 	})
 	t.Run("with something else", func(t *testing.T) {
 		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -124,8 +167,330 @@ func TestGetWithCtx(t *testing.T) {
 		}))
 		defer ts.Close()
 		ctx := context.Background()
-		_, err := getWithCtx(ctx, http.DefaultClient, ts.URL)
+		_, err := New().getWithCtx(ctx, ts.URL, nil)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), http.StatusText(http.StatusBadGateway))
+		assert.IsError(t, err, ErrServer)
+	})
+	t.Run("wrapped by the client", func(t *testing.T) {
+		ts := httptest.NewServer(newTestMux(t))
+		defer ts.Close()
+
+		c := New(WithBaseURL(ts.URL))
+
+		_, err := c.Runs(context.TODO(), 1)
+		assert.IsError(t, err, ErrNotFound)
+		_, err = c.Ads(context.TODO(), AGDQ2021.ID)
+		assert.IsError(t, err, ErrForbidden)
+	})
+}
+
+func TestGetInterviews(t *testing.T) {
+	ts := httptest.NewServer(newTestMux(t))
+	defer ts.Close()
+
+	c := New(WithBaseURL(ts.URL))
+
+	ivs, err := c.Interviews(context.TODO(), AGDQ2021.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 93, len(ivs))
+
+	iv := ivs[1]
+	assert.Equal(t, "Donkey Kong Country", iv.Topic)
+	assert.Equal(t, []string{"Spikevegeta"}, iv.Interviewers)
+	assert.Equal(t, []string{"Eazinn", "DadLovesBeer"}, iv.Subjects)
+	assert.Equal(t, 10*time.Second, iv.Length.Duration)
+	assert.Equal(t, 3, iv.Order)
+	assert.Equal(t, 11, iv.Suborder)
+	assert.True(t, iv.Public)
+	assert.False(t, iv.Prerecorded)
+}
+
+func TestGetTimeline(t *testing.T) {
+	ts := httptest.NewServer(newTestMux(t))
+	defer ts.Close()
+
+	c := New(WithBaseURL(ts.URL))
+
+	tl, err := c.Timeline(context.TODO(), AGDQ2021.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 157+93, len(tl.Items))
+
+	first := tl.Items[0]
+	assert.Equal(t, KindRun, first.Kind)
+	assert.Equal(t, "Pre-Show", first.Run.Title)
+
+	after := tl.After(first.Run)
+	assert.Equal(t, 0, len(after))
+
+	second := tl.Items[1]
+	after = tl.After(second.Run)
+	assert.Equal(t, 1, len(after))
+	assert.Equal(t, "Why Sent Has Them", after[0].Interview.Topic)
+	assert.Equal(t, second.Run.Start.Add(second.Run.RunTime.Duration), after[0].Start)
+}
+
+func TestGetBids(t *testing.T) {
+	ts := httptest.NewServer(newTestMux(t))
+	defer ts.Close()
+
+	c := New(WithBaseURL(ts.URL))
+
+	bids, err := c.Bids(context.TODO(), AGDQ2021.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(bids))
+
+	incentive := bids[0]
+	assert.False(t, incentive.IsBidWar())
+	assert.Equal(t, BidClosed, incentive.State)
+	assert.Equal(t, NewMoney(150000, ""), incentive.Goal)
+	assert.True(t, incentive.Remaining().IsZero())
+
+	war := bids[1]
+	assert.True(t, war.IsBidWar())
+	assert.Equal(t, 2, len(war.Options))
+	assert.Equal(t, "KONG", war.Leader().Name)
+	assert.Equal(t, war.RunID, war.Options[1].RunID)
+
+	open := bids[2]
+	assert.Equal(t, BidOpened, open.State)
+	assert.Equal(t, uint(0), open.RunID)
+	assert.Equal(t, NewMoney(1265450, ""), open.Remaining())
+
+	runs, err := c.Runs(context.TODO(), AGDQ2021.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []*Bid{incentive}, BidsForRun(bids, runs[1]))
+	assert.Equal(t, []*Bid{war}, BidsForRun(bids, runs[2]))
+	assert.Equal(t, 0, len(BidsForRun(bids, runs[0])))
+}
+
+func TestGetMilestones(t *testing.T) {
+	ts := httptest.NewServer(newTestMux(t))
+	defer ts.Close()
+
+	c := New(WithBaseURL(ts.URL))
+
+	ms, err := c.Milestones(context.TODO(), AGDQ2021.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(ms))
+	assert.Equal(t, "Two Million", ms[0].Name)
+	assert.Equal(t, uint(4700), ms[1].RunID)
+	assert.Equal(t, NewMoney(250000000, ""), ms[2].Amount)
+	assert.False(t, ms[2].Visible)
+}
+
+func TestGetPrizes(t *testing.T) {
+	ts := httptest.NewServer(newTestMux(t))
+	defer ts.Close()
+
+	c := New(WithBaseURL(ts.URL))
+
+	prizes, err := c.Prizes(context.TODO(), AGDQ2021.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(prizes))
+	assert.Equal(t, uint(4662), prizes[0].StartRunID)
+	assert.Equal(t, NewMoney(2500, ""), prizes[1].MinimumBid)
+	assert.False(t, prizes[1].StartTime.IsZero())
+	assert.True(t, prizes[2].EndTime.IsZero())
+
+	s, err := c.Schedule(context.TODO(), AGDQ2021.ID)
+	assert.NoError(t, err)
+
+	during := time.Date(2021, 1, 3, 13, 0, 0, 0, time.FixedZone("EST", -5*60*60))
+	assert.Equal(t, prizes, AvailablePrizes(prizes, s, during))
+
+	after := time.Date(2021, 1, 3, 16, 0, 0, 0, time.FixedZone("EST", -5*60*60))
+	assert.Equal(t, prizes[1:], AvailablePrizes(prizes, s, after))
+}
+
+func TestGetDonations(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/events/34/donations/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "":
+			fmt.Fprintf(w, `{"count":3,"next":"http://%s/events/34/donations/?page=2","previous":null,"results":[`+
+				`{"id":1,"donor_name":"(Anonymous)","amount":5.0,"currency":"USD","timereceived":"2021-01-03T11:31:00-05:00","comment":""},`+
+				`{"id":2,"donor_name":"someone","amount":"25.50","currency":"USD","timereceived":"2021-01-03T11:32:00-05:00","comment":"Good luck!"}]}`, r.Host)
+		case "2":
+			fmt.Fprint(w, `{"count":3,"next":null,"previous":null,"results":[`+
+				`{"id":3,"donor_name":"someone else","amount":100,"currency":"USD","timereceived":"2021-01-03T11:33:00-05:00","comment":""}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"detail":"Invalid page."}`)
+		}
+	})
+	mux.HandleFunc("/events/35/donations/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		fmt.Fprintf(w, `{"count":2,"next":"http://%s/events/35/donations/?page=2","previous":null,"results":[`+
+			`{"id":1,"donor_name":"(Anonymous)","amount":5.0,"currency":"USD","timereceived":"2021-01-03T11:31:00-05:00","comment":""}]}`, r.Host)
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	c := New(WithBaseURL(ts.URL))
+
+	t.Run("all pages", func(t *testing.T) {
+		var dons []*DonationEntry
+		for d, err := range c.Donations(context.TODO(), AGDQ2021.ID) {
+			assert.NoError(t, err)
+			dons = append(dons, d)
+		}
+		assert.Equal(t, 3, len(dons))
+		assert.Equal(t, "someone", dons[1].Donor)
+		assert.Equal(t, NewMoney(2550, "USD"), dons[1].Amount)
+		assert.Equal(t, "Good luck!", dons[1].Comment)
+		assert.Equal(t, uint(3), dons[2].ID)
+	})
+	t.Run("stop early", func(t *testing.T) {
+		count := 0
+		for range c.Donations(context.TODO(), AGDQ2021.ID) {
+			count++
+			break
+		}
+		assert.Equal(t, 1, count)
+	})
+	t.Run("with error", func(t *testing.T) {
+		var dons []*DonationEntry
+		var errs []error
+		for d, err := range c.Donations(context.TODO(), SGDQ2021.ID) {
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			dons = append(dons, d)
+		}
+		assert.Equal(t, 1, len(dons))
+		assert.Equal(t, 1, len(errs))
+	})
+}
+
+func TestCurrentEvent(t *testing.T) {
+	now := time.Now().UTC()
+	starts := map[uint]time.Time{
+		1: now.Add(-100 * 24 * time.Hour),
+		2: now.Add(-time.Hour),
+		3: now.Add(10 * 24 * time.Hour),
+	}
+
+	serve := func(ids ...uint) *httptest.Server {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/events/", func(w http.ResponseWriter, r *http.Request) {
+			var evs []string
+			for _, id := range ids {
+				evs = append(evs, fmt.Sprintf(`{"id":%d,"datetime":%q}`, id, starts[id].Format(time.RFC3339)))
+			}
+			fmt.Fprintf(w, `{"count":%d,"next":null,"previous":null,"results":[%s]}`, len(evs), strings.Join(evs, ","))
+		})
+		for _, id := range ids {
+			mux.HandleFunc(fmt.Sprintf("/events/%d/", id), func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, `{"id":%d,"datetime":%q}`, id, starts[id].Format(time.RFC3339))
+			})
+			mux.HandleFunc(fmt.Sprintf("/events/%d/runs/", id), func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, `{"count":1,"next":null,"previous":null,"results":[{"id":%d,"starttime":%q,"run_time":"2:00:00","setup_time":"0:00:00"}]}`, id, starts[id].Format(time.RFC3339))
+			})
+		}
+		ts := httptest.NewServer(mux)
+		t.Cleanup(ts.Close)
+		return ts
+	}
+
+	for name, tc := range map[string]struct {
+		ids    []uint
+		want   uint
+		status EventStatus
+	}{
+		"live":     {ids: []uint{3, 1, 2}, want: 2, status: StatusLive},
+		"upcoming": {ids: []uint{3, 1}, want: 3, status: StatusUpcoming},
+		"finished": {ids: []uint{1}, want: 1, status: StatusFinished},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ts := serve(tc.ids...)
+			ev, err := New(WithBaseURL(ts.URL)).CurrentEvent(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, ev.ID)
+			assert.Equal(t, tc.status, ev.Status(now))
+		})
+	}
+
+	t.Run("none", func(t *testing.T) {
+		ts := serve()
+		_, err := New(WithBaseURL(ts.URL)).CurrentEvent(context.Background())
+		assert.IsError(t, err, ErrNoEvents)
+	})
+}
+
+func TestGetTalent(t *testing.T) {
+	ts := httptest.NewServer(newTestMux(t))
+	defer ts.Close()
+
+	c := New(WithBaseURL(ts.URL))
+
+	t.Run("known", func(t *testing.T) {
+		tal, err := c.Talent(context.TODO(), 884)
+		assert.NoError(t, err)
+		assert.Equal(t, &Talent{
+			ID:       884,
+			Name:     "Kungfufruitcup",
+			Pronouns: "she/her",
+			Stream:   "https://twitch.tv/kungfufruitcup",
+			Platform: "TWITCH",
+			Twitter:  "kungfufruitcup",
+			YouTube:  "kungfufruitcup",
+		}, tal)
+		assert.Equal(t, "Kungfufruitcup (she/her)", tal.String())
+	})
+	t.Run("unknown", func(t *testing.T) {
+		_, err := c.Talent(context.TODO(), 1)
+		assert.Error(t, err)
+	})
+	t.Run("from runs", func(t *testing.T) {
+		runs, err := c.Runs(context.TODO(), AGDQ2021.ID)
+		assert.NoError(t, err)
+		tal := runs[0].Runners[4]
+		assert.Equal(t, uint(884), tal.ID)
+		assert.Equal(t, "she/her", tal.Pronouns)
+		assert.Equal(t, "https://twitch.tv/kungfufruitcup", tal.Stream)
+	})
+}
+
+func TestOptions(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		c := New()
+		assert.Equal(t, http.DefaultClient, c.c)
+		assert.Equal(t, trackerV2, c.baseURL)
+		assert.Equal(t, time.Minute, c.timeout)
+	})
+	t.Run("base URL", func(t *testing.T) {
+		c := New(WithBaseURL("http://localhost:8080/tracker/api/v2/"))
+		assert.Equal(t, "http://localhost:8080/tracker/api/v2", c.baseURL)
+	})
+	t.Run("user agent", func(t *testing.T) {
+		var ua string
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ua = r.UserAgent()
+		}))
+		defer ts.Close()
+		_, err := New(WithUserAgent("gdq-test (test@example.com)")).getWithCtx(context.Background(), ts.URL, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "gdq-test (test@example.com)", ua)
+	})
+	t.Run("timeout", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		}))
+		defer ts.Close()
+		_, err := New(WithTimeout(10*time.Millisecond)).getWithCtx(context.Background(), ts.URL, nil)
+		assert.IsError(t, err, context.DeadlineExceeded)
+	})
+	t.Run("HTTP client", func(t *testing.T) {
+		hc := &http.Client{}
+		assert.Equal(t, hc, New(WithHTTPClient(hc)).c)
+		assert.Equal(t, http.DefaultClient, New(WithHTTPClient(nil)).c)
 	})
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"flag"
 	"fmt"
-	"net/http"
 	"os"
 	"slices"
 	"strconv"
@@ -26,7 +25,7 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	c := gdq.New(http.DefaultClient)
+	c := gdq.New()
 	evs, err := c.Events(ctx)
 	if err != nil {
 		panic(err)
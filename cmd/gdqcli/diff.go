@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/daenney/gdq/v3"
+)
+
+// runDiff implements the diff subcommand, which compares two schedules
+// saved with -format json.
+func runDiff(args []string) {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	format := fs.String("format", "table", "one of table or json")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage of %s diff:\n", os.Args[0])
+		fmt.Fprintf(fs.Output(), "  %s diff [flags] old.json new.json\n\n", os.Args[0])
+		fs.PrintDefaults()
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), "Compares two schedules saved with -format json and shows which runs were added, removed, moved, reestimated, or had their talent or category changed.")
+	}
+	fs.Parse(args)
+
+	if fs.NArg() != 2 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	cs := gdq.Diff(readSchedule(fs.Arg(0)), readSchedule(fs.Arg(1)))
+
+	switch strings.ToLower(*format) {
+	case "table":
+		if cs.Empty() {
+			return
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', tabwriter.Debug)
+		fmt.Fprintln(tw, "Change\tTitle\tBefore\tAfter")
+		for _, run := range cs.Added {
+			fmt.Fprintf(tw, "added\t%s\t\t%s\n", run.Title, run.Start.Local().Format(time.Stamp))
+		}
+		for _, run := range cs.Removed {
+			fmt.Fprintf(tw, "removed\t%s\t%s\t\n", run.Title, run.Start.Local().Format(time.Stamp))
+		}
+		for _, c := range cs.Moved {
+			fmt.Fprintf(tw, "moved\t%s\t%s\t%s\n", c.New.Title, c.Old.Start.Local().Format(time.Stamp), c.New.Start.Local().Format(time.Stamp))
+		}
+		for _, c := range cs.Reestimated {
+			fmt.Fprintf(tw, "reestimated\t%s\t%s\t%s\n", c.New.Title, c.Old.Estimate, c.New.Estimate)
+		}
+		for _, c := range cs.TalentChanged {
+			fmt.Fprintf(tw, "talent\t%s\t%s\t%s\n", c.New.Title, talent(c.Old), talent(c.New))
+		}
+		for _, c := range cs.CategoryChanged {
+			fmt.Fprintf(tw, "category\t%s\t%s\t%s\n", c.New.Title, c.Old.Category, c.New.Category)
+		}
+		tw.Flush()
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "    ")
+		if err := enc.Encode(cs); err != nil {
+			log.Fatalln(err)
+		}
+	default:
+		log.Fatalf("unrecognised value for format flag: %s\n", *format)
+	}
+}
+
+// readSchedule reads a schedule saved with -format json, with or without
+// -talent.
+func readSchedule(path string) *gdq.Schedule {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	var matches []gdq.TalentMatch
+	if err := json.Unmarshal(data, &matches); err == nil && len(matches) > 0 && !slices.ContainsFunc(matches, func(m gdq.TalentMatch) bool { return m.Run == nil }) {
+		runs := make([]*gdq.Run, 0, len(matches))
+		for _, m := range matches {
+			runs = append(runs, m.Run)
+		}
+		return gdq.NewScheduleFrom(runs)
+	}
+
+	var runs []*gdq.Run
+	if err := json.Unmarshal(data, &runs); err != nil {
+		log.Fatalf("Could not read schedule from %s: %s\n", path, err)
+	}
+	return gdq.NewScheduleFrom(runs)
+}
+
+// talent lists the runners, hosts and commentators of a run.
+func talent(run *gdq.Run) string {
+	var res []string
+	for _, role := range []struct {
+		name   string
+		talent []gdq.Talent
+	}{
+		{"runners", run.Runners},
+		{"hosts", run.Hosts},
+		{"commentators", run.Commentators},
+	} {
+		if len(role.talent) > 0 {
+			res = append(res, fmt.Sprintf("%s: %s", role.name, names(role.talent)))
+		}
+	}
+	return strings.Join(res, "; ")
+}
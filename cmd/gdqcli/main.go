@@ -3,31 +3,48 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/daenney/gdq/v3"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		runDiff(os.Args[2:])
+		return
+	}
+
 	host := flag.String("host", "", "show runs matching this host")
 	runner := flag.String("runner", "", "show runs matching this runner")
+	commentator := flag.String("commentator", "", "show runs matching this commentator")
+	talent := flag.String("talent", "", "show runs matching this runner, host or commentator, together with their roles in them")
 	title := flag.String("title", "", "show runs matching this title")
-	category := flag.Bool("show-category", false, "show category in the output")
-	platform := flag.Bool("show-platform", false, "show platform in the output")
+	category := flag.String("category", "", "show runs matching this category")
+	platform := flag.String("platform", "", "show runs matching this platform")
+	query := flag.String("query", "", "show runs matching this query, like 'runner:foo OR (host:bar AND NOT platform:snes)'. Supported fields are title, runner, host, commentator, category, platform and tag")
+	showCategory := flag.Bool("show-category", false, "show category in the output")
+	showPlatform := flag.Bool("show-platform", false, "show platform in the output")
 	format := flag.String("format", "table", "one of table or json")
 	event := flag.String("event", "", "GDQ event to query. This can be a string or a event number and when omitted will result in the current/upcoming schedule being used")
 	showVersion := flag.Bool("version", false, "show CLI version and build info")
+	cache := flag.Bool("cache", false, "cache responses from the tracker in the user's cache directory, so they can be used with -offline or when the tracker is down. Implied by -offline and -max-age")
+	offline := flag.Bool("offline", false, "don't query the tracker, use the data cached by previous queries instead")
+	maxAge := flag.Duration("max-age", 0, "how old cached data may be. Cached data younger than this is used without querying the tracker, and with -offline older data is refused. When 0, cached data is always checked with the tracker, or with -offline used regardless of its age")
 	userAgent := flag.String("user-agent", "", "user-agent to use when querying. If omitted it'll use Go's default user-agent. Set this to something GDQ staff can contact you at in case your usage causes a problem")
 	flag.Usage = func() {
 		fmt.Fprintf(flag.CommandLine.Output(), "Usage of %s:\n", os.Args[0])
 		flag.PrintDefaults()
 		fmt.Fprintln(flag.CommandLine.Output())
-		fmt.Fprintln(flag.CommandLine.Output(), "When using filters, each filter is applied and the resulting filtered schedule is then filtered with the next filter. This means filters are additive, so you can't say show me runs for this host or this runner.")
+		fmt.Fprintf(flag.CommandLine.Output(), "To compare two schedules saved with -format json, use: %s diff old.json new.json\n", os.Args[0])
+		fmt.Fprintln(flag.CommandLine.Output())
+		fmt.Fprintln(flag.CommandLine.Output(), "When using filters, each filter is applied and the resulting filtered schedule is then filtered with the next filter. This means filters are additive, so you can't say show me runs for this host or this runner. Use -query for that, which supports AND, OR, NOT and parentheses.")
 		fmt.Fprintln(flag.CommandLine.Output())
 		fmt.Fprintln(flag.CommandLine.Output(), "All filters use a case insensitive substring match. This means that passing a filter of '-runner e' will find all runs where any runner has the letter 'e' in their handle.")
 	}
@@ -42,14 +59,38 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	g := gdq.New(newHTTPClient(*userAgent))
+	opts := []gdq.Option{
+		gdq.WithUserAgent(*userAgent),
+		gdq.WithTimeout(30 * time.Second),
+	}
+	if *cache || *offline || *maxAge > 0 {
+		dir, err := gdq.DefaultCacheDir()
+		if err != nil {
+			log.Fatalf("Could not determine the cache directory: %s\n", err)
+		}
+		opts = append(opts, gdq.WithCache(*maxAge), gdq.WithDiskCache(dir))
+	}
+	if *offline {
+		opts = append(opts, gdq.WithOffline(*maxAge))
+	}
+
+	var match func(*gdq.Run) bool
+	if *query != "" {
+		var err error
+		match, err = gdq.ParseQuery(*query)
+		if err != nil {
+			log.Fatalln(err)
+		}
+	}
+
+	g := gdq.New(opts...)
 	var ev *gdq.Event
 	if *event == "" {
-		v, err := g.Events(ctx)
+		v, err := g.CurrentEvent(ctx)
 		if err != nil {
 			log.Fatalln(err)
 		}
-		ev = v[0]
+		ev = v
 	} else {
 		v, ok := gdq.GetEventByName(*event)
 		if !ok {
@@ -70,28 +111,52 @@ func main() {
 
 	schedule, err := g.Schedule(ctx, ev.ID)
 	if err != nil {
+		if errors.Is(err, gdq.ErrNoRuns) {
+			log.Printf("No runs for event with ID %d: (%s)\n", ev.ID, ev.String())
+			os.Exit(0)
+		}
+		if errors.Is(err, gdq.ErrNotFound) {
+			log.Fatalf("Could not find an event with ID %d\n", ev.ID)
+		}
 		log.Fatalln(err)
 	}
 
-	if len(schedule.Runs) == 0 {
-		log.Printf("No runs for event with ID %d: (%s)\n", ev.ID, ev.String())
-		os.Exit(0)
-	}
-
 	if *runner != "" {
 		schedule = schedule.ForRunner(*runner)
 	}
 	if *host != "" {
 		schedule = schedule.ForHost(*host)
 	}
+	if *commentator != "" {
+		schedule = schedule.ForCommentator(*commentator)
+	}
+	var roles map[*gdq.Run][]gdq.Role
+	if *talent != "" {
+		roles = map[*gdq.Run][]gdq.Role{}
+		var runs []*gdq.Run
+		for _, m := range schedule.ForTalent(*talent) {
+			runs = append(runs, m.Run)
+			roles[m.Run] = m.Roles
+		}
+		schedule = gdq.NewScheduleFrom(runs)
+	}
 	if *title != "" {
 		schedule = schedule.ForTitle(*title)
 	}
+	if *category != "" {
+		schedule = schedule.ForCategory(*category)
+	}
+	if *platform != "" {
+		schedule = schedule.ForPlatform(*platform)
+	}
+	if match != nil {
+		schedule = schedule.Filter(match)
+	}
 
 	if schedule != nil && len(schedule.Runs) > 0 {
 		switch strings.ToLower(*format) {
 		case "table":
-			w := newWriter(*category, *platform)
+			w := newWriter(*showCategory, *showPlatform, roles)
 			for _, run := range schedule.Runs {
 				w.Write(run)
 			}
@@ -99,7 +164,15 @@ func main() {
 		case "json":
 			enc := json.NewEncoder(os.Stdout)
 			enc.SetIndent("", "    ")
-			if err := enc.Encode(schedule.Runs); err != nil {
+			var v any = schedule.Runs
+			if roles != nil {
+				matches := make([]gdq.TalentMatch, 0, len(schedule.Runs))
+				for _, run := range schedule.Runs {
+					matches = append(matches, gdq.TalentMatch{Run: run, Roles: roles[run]})
+				}
+				v = matches
+			}
+			if err := enc.Encode(v); err != nil {
 				log.Fatalln(err)
 			}
 		default:
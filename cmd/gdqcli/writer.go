@@ -14,21 +14,28 @@ type writer struct {
 	tw       *tabwriter.Writer
 	category bool
 	platform bool
+	roles    map[*gdq.Run][]gdq.Role
 }
 
-func newWriter(category bool, platform bool) *writer {
+// newWriter returns a writer for a table of runs. When roles isn't nil, a
+// column with the roles of every run is added.
+func newWriter(category bool, platform bool, roles map[*gdq.Run][]gdq.Role) *writer {
 	w := &writer{tw: tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', tabwriter.Debug)}
 	w.category = category
 	w.platform = platform
-	if !w.platform && !w.category {
-		fmt.Fprintln(w.tw, "Start Time\tTitle\tEstimate\tRunners\tHosts\tCommentators")
-	} else if w.platform && w.category {
-		fmt.Fprintln(w.tw, "Start Time\tTitle\tEstimate\tRunners\tHosts\tCommentators\tConsole\tCategory")
-	} else if w.platform {
-		fmt.Fprintln(w.tw, "Start Time\tTitle\tEstimate\tRunners\tHosts\tCommentators\tConsole")
-	} else if w.category {
-		fmt.Fprintln(w.tw, "Start Time\tTitle\tEstimate\tRunners\tHosts\tCommentators\tCategory")
+	w.roles = roles
+
+	cols := []string{"Start Time", "Title", "Estimate", "Runners", "Hosts", "Commentators"}
+	if w.platform {
+		cols = append(cols, "Console")
+	}
+	if w.category {
+		cols = append(cols, "Category")
+	}
+	if w.roles != nil {
+		cols = append(cols, "Roles")
 	}
+	fmt.Fprintln(w.tw, strings.Join(cols, "\t"))
 	return w
 }
 
@@ -37,54 +44,24 @@ func (w *writer) Flush() {
 }
 
 func (w *writer) Write(run *gdq.Run) {
-	if !w.platform && !w.category {
-		fmt.Fprintf(w.tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
-			run.Start.Local().Format(time.Stamp),
-			run.Title,
-			run.Estimate,
-			names(run.Runners),
-			names(run.Hosts),
-			names(run.Commentators),
-		)
-		return
-	}
-	if w.platform && w.category {
-		fmt.Fprintf(w.tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
-			run.Start.Local().Format(time.Stamp),
-			run.Title,
-			run.Estimate,
-			names(run.Runners),
-			names(run.Hosts),
-			names(run.Commentators),
-			run.Platform,
-			run.Category,
-		)
-		return
+	cols := []string{
+		run.Start.Local().Format(time.Stamp),
+		run.Title,
+		run.Estimate.String(),
+		names(run.Runners),
+		names(run.Hosts),
+		names(run.Commentators),
 	}
 	if w.platform {
-		fmt.Fprintf(w.tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
-			run.Start.Local().Format(time.Stamp),
-			run.Title,
-			run.Estimate,
-			names(run.Runners),
-			names(run.Hosts),
-			names(run.Commentators),
-			run.Platform,
-		)
-		return
+		cols = append(cols, run.Platform)
 	}
 	if w.category {
-		fmt.Fprintf(w.tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
-			run.Start.Local().Format(time.Stamp),
-			run.Title,
-			run.Estimate,
-			names(run.Runners),
-			names(run.Hosts),
-			names(run.Commentators),
-			run.Category,
-		)
-		return
+		cols = append(cols, run.Category)
 	}
+	if w.roles != nil {
+		cols = append(cols, roles(w.roles[run]))
+	}
+	fmt.Fprintln(w.tw, strings.Join(cols, "\t"))
 }
 
 func names(ts []gdq.Talent) string {
@@ -94,3 +71,11 @@ func names(ts []gdq.Talent) string {
 	}
 	return strings.Join(res, ", ")
 }
+
+func roles(rs []gdq.Role) string {
+	res := make([]string, 0, len(rs))
+	for _, r := range rs {
+		res = append(res, r.String())
+	}
+	return strings.Join(res, ", ")
+}
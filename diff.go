@@ -0,0 +1,117 @@
+package gdq
+
+import (
+	"slices"
+)
+
+// Changeset is the set of changes between two versions of a [Schedule].
+//
+// Runs are matched up by their ID, so a run that's renamed is still the
+// same run. Runs without an ID, like those in schedules saved before runs
+// had one, are matched up by their title and start time instead, so moving
+// such a run shows up as removing it and adding it again. A run can be part
+// of more than one kind of change, when it's both moved and reestimated for
+// example. Removed runs are in the order of the older schedule, every other
+// kind of change is in the order of the newer schedule.
+type Changeset struct {
+	Added           []*Run      `json:"added,omitempty"`
+	Removed         []*Run      `json:"removed,omitempty"`
+	Moved           []RunChange `json:"moved,omitempty"`
+	Reestimated     []RunChange `json:"reestimated,omitempty"`
+	TalentChanged   []RunChange `json:"talent_changed,omitempty"`
+	CategoryChanged []RunChange `json:"category_changed,omitempty"`
+}
+
+// RunChange is a run before and after it changed.
+type RunChange struct {
+	Old *Run `json:"old"`
+	New *Run `json:"new"`
+}
+
+// Diff returns the changes between an older and a newer version of a
+// schedule. Either of them may be nil.
+func Diff(before, after *Schedule) *Changeset {
+	cs := &Changeset{}
+	for _, c := range diff(before, after) {
+		rc := RunChange{Old: c.Old, New: c.Run}
+		switch c.Kind {
+		case RunAdded:
+			cs.Added = append(cs.Added, c.Run)
+		case RunRemoved:
+			cs.Removed = append(cs.Removed, c.Old)
+		case RunMoved:
+			cs.Moved = append(cs.Moved, rc)
+		case RunReestimated:
+			cs.Reestimated = append(cs.Reestimated, rc)
+		case RunTalentChanged:
+			cs.TalentChanged = append(cs.TalentChanged, rc)
+		case RunCategoryChanged:
+			cs.CategoryChanged = append(cs.CategoryChanged, rc)
+		}
+	}
+	return cs
+}
+
+// Empty returns whether there are no changes.
+func (cs *Changeset) Empty() bool {
+	return len(cs.Added) == 0 && len(cs.Removed) == 0 && len(cs.Moved) == 0 &&
+		len(cs.Reestimated) == 0 && len(cs.TalentChanged) == 0 && len(cs.CategoryChanged) == 0
+}
+
+// diff returns the changes between two versions of a schedule, in the order
+// of the runs in the new schedule. Removed runs come last, in the order of
+// the old schedule.
+//
+// Runs are matched up by their [diffKey].
+func diff(before, after *Schedule) []ScheduleChange {
+	prev := map[diffID]*Run{}
+	for _, run := range before.runs() {
+		prev[diffKey(run)] = run
+	}
+
+	var changes []ScheduleChange
+	seen := map[diffID]bool{}
+	for _, run := range after.runs() {
+		seen[diffKey(run)] = true
+		o, ok := prev[diffKey(run)]
+		if !ok {
+			changes = append(changes, ScheduleChange{Kind: RunAdded, Run: run})
+			continue
+		}
+		if !o.Start.Equal(run.Start) {
+			changes = append(changes, ScheduleChange{Kind: RunMoved, Run: run, Old: o})
+		}
+		if o.Estimate != run.Estimate {
+			changes = append(changes, ScheduleChange{Kind: RunReestimated, Run: run, Old: o})
+		}
+		if !slices.Equal(o.Runners, run.Runners) || !slices.Equal(o.Hosts, run.Hosts) || !slices.Equal(o.Commentators, run.Commentators) {
+			changes = append(changes, ScheduleChange{Kind: RunTalentChanged, Run: run, Old: o})
+		}
+		if o.Category != run.Category {
+			changes = append(changes, ScheduleChange{Kind: RunCategoryChanged, Run: run, Old: o})
+		}
+	}
+
+	for _, run := range before.runs() {
+		if !seen[diffKey(run)] {
+			changes = append(changes, ScheduleChange{Kind: RunRemoved, Old: run})
+		}
+	}
+	return changes
+}
+
+// diffID identifies a run across versions of a schedule.
+type diffID struct {
+	id    uint
+	title string
+	start int64
+}
+
+// diffKey returns the ID of the run, or its title and start time if it
+// doesn't have an ID.
+func diffKey(run *Run) diffID {
+	if run.ID != 0 {
+		return diffID{id: run.ID}
+	}
+	return diffID{title: run.Title, start: run.Start.UnixNano()}
+}
@@ -0,0 +1,62 @@
+package gdq
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestDiff(t *testing.T) {
+	start := testStart
+	before := NewScheduleFrom([]*Run{
+		{ID: 1, Title: "Pre-Show", Start: start, Estimate: Duration{30 * time.Minute}},
+		{ID: 2, Title: "Mirror's Edge", Start: start.Add(30 * time.Minute), Estimate: Duration{time.Hour}, Runners: []Talent{{Name: "Hekigan"}}},
+		{ID: 3, Title: "Celeste", Start: start.Add(90 * time.Minute), Estimate: Duration{time.Hour}, Category: "Any%"},
+	})
+	after := NewScheduleFrom([]*Run{
+		{ID: 2, Title: "Mirror's Edge", Start: start, Estimate: Duration{45 * time.Minute}, Runners: []Talent{{Name: "Hekigan"}, {Name: "Kungfufruitcup"}}},
+		{ID: 4, Title: "Portal", Start: start.Add(45 * time.Minute), Estimate: Duration{time.Hour}},
+		{ID: 3, Title: "Celeste", Start: start.Add(90 * time.Minute), Estimate: Duration{time.Hour}},
+	})
+
+	var got []string
+	for _, c := range diff(before, after) {
+		run := c.Run
+		if run == nil {
+			run = c.Old
+		}
+		got = append(got, fmt.Sprintf("%d %s", run.ID, c.Kind))
+	}
+	assert.Equal(t, []string{"2 moved", "2 reestimated", "2 talent changed", "4 added", "3 category changed", "1 removed"}, got)
+
+	cs := Diff(before, after)
+	assert.Equal(t, []*Run{after.Runs[1]}, cs.Added)
+	assert.Equal(t, []*Run{before.Runs[0]}, cs.Removed)
+	assert.Equal(t, []RunChange{{Old: before.Runs[1], New: after.Runs[0]}}, cs.Moved)
+	assert.Equal(t, cs.Moved, cs.Reestimated)
+	assert.Equal(t, cs.Moved, cs.TalentChanged)
+	assert.Equal(t, []RunChange{{Old: before.Runs[2], New: after.Runs[2]}}, cs.CategoryChanged)
+	assert.False(t, cs.Empty())
+
+	assert.True(t, Diff(before, before).Empty())
+	assert.Equal(t, 3, len(Diff(nil, before).Added))
+	assert.Equal(t, 3, len(Diff(before, nil).Removed))
+
+	t.Run("without IDs", func(t *testing.T) {
+		before := NewScheduleFrom([]*Run{
+			{Title: "Pre-Show", Start: start, Estimate: Duration{30 * time.Minute}},
+			{Title: "Celeste", Start: start.Add(30 * time.Minute), Estimate: Duration{time.Hour}},
+		})
+		after := NewScheduleFrom([]*Run{
+			{Title: "Celeste", Start: start.Add(30 * time.Minute), Estimate: Duration{45 * time.Minute}},
+			{Title: "Portal", Start: start.Add(75 * time.Minute), Estimate: Duration{time.Hour}},
+		})
+		cs := Diff(before, after)
+		assert.Equal(t, []*Run{after.Runs[1]}, cs.Added)
+		assert.Equal(t, []*Run{before.Runs[0]}, cs.Removed)
+		assert.Equal(t, []RunChange{{Old: before.Runs[1], New: after.Runs[0]}}, cs.Reestimated)
+		assert.Zero(t, cs.Moved)
+	})
+}
@@ -1,6 +1,43 @@
 package gdq
 
+import (
+	"time"
+)
+
+// Donation represents the donations received for an event.
 type Donation struct {
-	Count  uint64  `json:"count"`
-	Amount float64 `json:"amount"`
+	Count  uint64 `json:"count"`
+	Amount Money  `json:"amount"`
+}
+
+type donationResult struct {
+	ID           uint      `json:"id"`
+	Donor        string    `json:"donor_name"`
+	Amount       Money     `json:"amount"`
+	Currency     string    `json:"currency"`
+	TimeReceived time.Time `json:"timereceived"`
+	Comment      string    `json:"comment"`
+}
+
+func (r donationResult) toDonationEntry() *DonationEntry {
+	return &DonationEntry{
+		ID:       r.ID,
+		Donor:    r.Donor,
+		Amount:   r.Amount.WithCurrency(r.Currency),
+		Received: r.TimeReceived,
+		Comment:  r.Comment,
+	}
+}
+
+// DonationEntry represents a single donation to a GDQ event, as opposed to
+// the [Donation] totals of an event.
+//
+// Donor is the name the donor chose to be displayed under. Comment is empty
+// if the donor didn't leave one or it hasn't been approved.
+type DonationEntry struct {
+	ID       uint      `json:"id"`
+	Donor    string    `json:"donor"`
+	Amount   Money     `json:"amount"`
+	Received time.Time `json:"received"`
+	Comment  string    `json:"comment"`
 }
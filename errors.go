@@ -0,0 +1,78 @@
+package gdq
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Errors that an [APIError] matches with [errors.Is], depending on the
+// status code the tracker responded with.
+var (
+	ErrBadRequest  = errors.New("bad request")
+	ErrForbidden   = errors.New("forbidden")
+	ErrNotFound    = errors.New("not found")
+	ErrRateLimited = errors.New("rate limited")
+	ErrServer      = errors.New("server error")
+)
+
+// Errors returned when the tracker responds successfully, but there's
+// nothing there.
+var (
+	ErrNoEvents     = errors.New("there are no known events")
+	ErrNoRuns       = errors.New("there are no runs")
+	ErrNoInterviews = errors.New("there are no interviews")
+	ErrNoAds        = errors.New("there are no ads")
+	ErrNoBids       = errors.New("there are no bids")
+	ErrNoMilestones = errors.New("there are no milestones")
+	ErrNoPrizes     = errors.New("there are no prizes")
+)
+
+// ErrMixedCurrencies is returned when doing arithmetic on or comparing
+// [Money] in different currencies.
+var ErrMixedCurrencies = errors.New("mixed currencies")
+
+// ErrNotCached is returned by a [Client] in offline mode when there's no
+// cached response for a request, or it's too old.
+var ErrNotCached = errors.New("not in cache")
+
+// APIError is returned when the tracker responds with anything other than
+// a 200 OK.
+//
+// Detail is the error message the tracker included in its response, if any.
+// Body is the raw body of the response. RetryAfter is how long the tracker
+// asked us to wait before trying again, or 0 if it didn't.
+type APIError struct {
+	StatusCode int
+	Endpoint   string
+	Detail     string
+	Body       []byte
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	if e.Detail == "" {
+		return fmt.Sprintf("received unexpected status code: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Detail)
+}
+
+// Is allows matching an APIError against the sentinel errors for the
+// status code it represents.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrForbidden:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return e.StatusCode >= 500
+	default:
+		return false
+	}
+}
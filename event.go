@@ -11,41 +11,66 @@ type eventResp struct {
 	Short          string    `json:"short"`
 	Name           string    `json:"name"`
 	StartTime      time.Time `json:"datetime"`
-	DonationAmount float64   `json:"amount"`
+	Timezone       string    `json:"timezone"`
+	ReceiverName   string    `json:"receivername"`
+	TargetAmount   Money     `json:"targetamount"`
+	Currency       string    `json:"paypalcurrency"`
+	AllowDonations bool      `json:"allow_donations"`
+	DonationAmount Money     `json:"amount"`
 	DonationCount  uint64    `json:"donation_count"`
 }
 
 func (e eventResp) toEvent() *Event {
 	return &Event{
-		ID:    e.ID,
-		Short: e.Short,
-		Name:  e.Name,
-		Year:  e.StartTime.Year(),
+		ID:            e.ID,
+		Short:         e.Short,
+		Name:          e.Name,
+		Year:          e.StartTime.Year(),
+		Start:         e.StartTime,
+		Timezone:      e.Timezone,
+		Charity:       e.ReceiverName,
+		Target:        e.TargetAmount.WithCurrency(e.Currency),
+		Currency:      e.Currency,
+		DonationsOpen: e.AllowDonations,
 		Donations: Donation{
-			Amount: e.DonationAmount,
+			Amount: e.DonationAmount.WithCurrency(e.Currency),
 			Count:  e.DonationCount,
 		},
 	}
 }
 
-type eventsResp struct {
-	Results []eventResp `json:"results"`
-}
+type eventsResp []eventResp
 
 func (e eventsResp) toEvents() []*Event {
-	evs := make([]*Event, 0, len(e.Results))
-	for _, r := range e.Results {
+	evs := make([]*Event, 0, len(e))
+	for _, r := range e {
 		evs = append(evs, r.toEvent())
 	}
 	return evs
 }
 
 // Event is the schedule ID of a GDQ event
+//
+// Timezone is the IANA name of the timezone of the venue, like
+// America/New_York. Charity is who the event raises money for, and Target
+// how much it hopes to raise, in Currency. Target is 0 if the event doesn't
+// have one.
+//
+// End is the end of the last run of the event. The tracker doesn't include
+// it, so it's only set by [Client.CurrentEvent]. Use [Client.EventEnd] or
+// [Schedule.End] to fill it in.
 type Event struct {
-	ID    uint   `json:"id"`
-	Short string `json:"short"`
-	Name  string `json:"name"`
-	Year  int    `json:"year"`
+	ID            uint      `json:"id"`
+	Short         string    `json:"short"`
+	Name          string    `json:"name"`
+	Year          int       `json:"year"`
+	Start         time.Time `json:"start"`
+	End           time.Time `json:"end"`
+	Timezone      string    `json:"timezone"`
+	Charity       string    `json:"charity"`
+	Target        Money     `json:"target"`
+	Currency      string    `json:"currency"`
+	DonationsOpen bool      `json:"donations_open"`
 
 	Donations Donation `json:"donations"`
 }
@@ -54,6 +79,109 @@ func (e Event) String() string {
 	return fmt.Sprintf("%s (%d)", e.Name, e.Year)
 }
 
+// EventStatus is where an [Event] is in its lifecycle.
+type EventStatus int
+
+const (
+	// StatusUnknown is the status of an event that has started, but whose
+	// end isn't known.
+	StatusUnknown EventStatus = iota
+	StatusUpcoming
+	StatusLive
+	StatusFinished
+)
+
+func (s EventStatus) String() string {
+	switch s {
+	case StatusUnknown:
+		return "unknown"
+	case StatusUpcoming:
+		return "upcoming"
+	case StatusLive:
+		return "live"
+	case StatusFinished:
+		return "finished"
+	default:
+		return fmt.Sprintf("EventStatus(%d)", int(s))
+	}
+}
+
+// Status returns the status of the event at now.
+//
+// Telling a live event from a finished one requires knowing when the event
+// ends. [Client.CurrentEvent] fills in End, for other events set it with
+// [Client.EventEnd] or [Schedule.End] first.
+func (e *Event) Status(now time.Time) EventStatus {
+	switch {
+	case e.Start.IsZero():
+		return StatusUnknown
+	case now.Before(e.Start):
+		return StatusUpcoming
+	case e.End.IsZero():
+		return StatusUnknown
+	case now.After(e.End):
+		return StatusFinished
+	default:
+		return StatusLive
+	}
+}
+
+// Location returns the timezone of the venue. It's UTC if the event doesn't
+// have a timezone, or it's one Go doesn't know about.
+func (e *Event) Location() *time.Location {
+	if e.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(e.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Day returns which day of the event t falls on, starting at 1 for the day
+// the event starts. Days follow the calendar in the timezone of the venue.
+//
+// It returns 0 if t is before the start of the event, or after its end when
+// that's known.
+func (e *Event) Day(t time.Time) int {
+	if e.Start.IsZero() || t.Before(e.Start) {
+		return 0
+	}
+	if !e.End.IsZero() && t.After(e.End) {
+		return 0
+	}
+	return calendarDays(e.Start, t, e.Location()) + 1
+}
+
+// Days returns the number of days the event spans, in the timezone of the
+// venue. It returns 0 if the start or the end of the event isn't known.
+func (e *Event) Days() int {
+	if e.Start.IsZero() || e.End.IsZero() {
+		return 0
+	}
+	return calendarDays(e.Start, e.End, e.Location()) + 1
+}
+
+// Progress returns the fraction of the donation target that's been raised.
+// It returns 0 if the event doesn't have a target.
+func (e *Event) Progress() float64 {
+	if e.Target.Cents() <= 0 {
+		return 0
+	}
+	return float64(e.Donations.Amount.Cents()) / float64(e.Target.Cents())
+}
+
+// calendarDays returns the number of midnights between from and to in loc.
+func calendarDays(from, to time.Time, loc *time.Location) int {
+	fy, fm, fd := from.In(loc).Date()
+	ty, tm, td := to.In(loc).Date()
+	// Noon UTC keeps daylight saving time out of the difference.
+	f := time.Date(fy, fm, fd, 12, 0, 0, 0, time.UTC)
+	u := time.Date(ty, tm, td, 12, 0, 0, 0, time.UTC)
+	return int(u.Sub(f).Hours() / 24)
+}
+
 // GetEventByName tries to find an event matching the input
 func GetEventByName(input string) (ev *Event, found bool) {
 	e, ok := eventsByName[strings.ToLower(input)]
@@ -3,6 +3,7 @@ package gdq
 import (
 	"math"
 	"testing"
+	"time"
 
 	"github.com/alecthomas/assert/v2"
 )
@@ -38,3 +39,50 @@ func TestGetEventByID(t *testing.T) {
 func TestEventString(t *testing.T) {
 	assert.Equal(t, "Awesome Games Done Quick (2016)", AGDQ2016.String())
 }
+
+func TestEventDay(t *testing.T) {
+	ev := &Event{
+		Start:    testStart,
+		End:      time.Date(2021, time.January, 10, 6, 0, 0, 0, time.UTC),
+		Timezone: "America/New_York",
+	}
+
+	assert.Equal(t, 0, ev.Day(ev.Start.Add(-time.Minute)))
+	assert.Equal(t, 1, ev.Day(ev.Start))
+	// 04:00 UTC is still the evening before in New York.
+	assert.Equal(t, 1, ev.Day(time.Date(2021, time.January, 4, 4, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 2, ev.Day(time.Date(2021, time.January, 4, 6, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 8, ev.Day(ev.End))
+	assert.Equal(t, 0, ev.Day(ev.End.Add(time.Minute)))
+	assert.Equal(t, 8, ev.Days())
+
+	ev.End = time.Time{}
+	assert.Equal(t, 10, ev.Day(time.Date(2021, time.January, 12, 12, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 0, ev.Days())
+}
+
+func TestEventLocation(t *testing.T) {
+	assert.Equal(t, time.UTC, (&Event{}).Location())
+	assert.Equal(t, time.UTC, (&Event{Timezone: "Nowhere/Special"}).Location())
+	assert.Equal(t, "America/New_York", (&Event{Timezone: "America/New_York"}).Location().String())
+}
+
+func TestEventProgress(t *testing.T) {
+	assert.Equal(t, 0.0, (&Event{Donations: Donation{Amount: NewMoney(10000, "USD")}}).Progress())
+	assert.Equal(t, 0.25, (&Event{Target: NewMoney(40000, "USD"), Donations: Donation{Amount: NewMoney(10000, "USD")}}).Progress())
+}
+
+func TestEventStatus(t *testing.T) {
+	start := testStart
+	ev := &Event{Start: start}
+
+	assert.Equal(t, StatusUnknown, (&Event{}).Status(start))
+	assert.Equal(t, StatusUpcoming, ev.Status(start.Add(-time.Minute)))
+	assert.Equal(t, StatusUnknown, ev.Status(start))
+
+	ev.End = start.Add(7 * 24 * time.Hour)
+	assert.Equal(t, StatusLive, ev.Status(start))
+	assert.Equal(t, StatusLive, ev.Status(ev.End))
+	assert.Equal(t, StatusFinished, ev.Status(ev.End.Add(time.Minute)))
+	assert.Equal(t, "finished", StatusFinished.String())
+}
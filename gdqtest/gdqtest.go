@@ -0,0 +1,449 @@
+// Package gdqtest provides a fake GDQ tracker for testing code that uses
+// the gdq package.
+//
+// The fake serves events, runs, interviews, talent and donations the same
+// way the tracker does, and can be told to fail in the ways the tracker
+// does:
+//
+//	srv := gdqtest.NewServer(t).
+//		AddEvent(&gdq.AGDQ2021).
+//		AddRuns(gdq.AGDQ2021.ID, &gdq.Run{Title: "Mirror's Edge"})
+//	srv.Fail("/events/34/runs/", gdqtest.ServerError().Times(1))
+//
+//	c := srv.Client(gdq.WithRetryPolicy(gdq.DefaultRetryPolicy))
+//	runs, err := c.Runs(ctx, gdq.AGDQ2021.ID)
+package gdqtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/daenney/gdq/v3"
+)
+
+// Server is a fake GDQ tracker.
+//
+// The Add methods return the server so calls can be chained. Records with
+// an ID of 0 get an ID assigned, which is written back to the value that
+// was passed in. The same goes for the Order of runs, which defaults to the
+// position of the run within its event.
+type Server struct {
+	*httptest.Server
+
+	l        sync.Mutex
+	pageSize int
+	nextID   uint
+	events   []*event
+	talent   map[uint]*gdq.Talent
+	failures map[string]*Failure
+	requests map[string]int
+}
+
+type event struct {
+	ev         *gdq.Event
+	runs       []*gdq.Run
+	interviews []*gdq.Interview
+	donations  []*gdq.DonationEntry
+}
+
+// NewServer starts a fake tracker. It's shut down when the test finishes.
+func NewServer(tb testing.TB) *Server {
+	tb.Helper()
+
+	s := &Server{
+		nextID:   1,
+		talent:   map[uint]*gdq.Talent{},
+		failures: map[string]*Failure{},
+		requests: map[string]int{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	tb.Cleanup(s.Close)
+	return s
+}
+
+// Client returns a client that talks to the fake tracker.
+//
+// The options are applied after pointing the client at the fake, so they
+// shouldn't include [gdq.WithBaseURL].
+func (s *Server) Client(opts ...gdq.Option) *gdq.Client {
+	return gdq.New(append([]gdq.Option{gdq.WithBaseURL(s.URL)}, opts...)...)
+}
+
+// SetPageSize sets the number of results on every page of a list endpoint.
+// It defaults to 0, which means everything is on a single page.
+func (s *Server) SetPageSize(n int) *Server {
+	s.l.Lock()
+	defer s.l.Unlock()
+	s.pageSize = n
+	return s
+}
+
+// AddEvent adds events to the tracker.
+//
+// The totals returned for an event are its Donations, regardless of the
+// donations added with [Server.AddDonations].
+func (s *Server) AddEvent(evs ...*gdq.Event) *Server {
+	s.l.Lock()
+	defer s.l.Unlock()
+	for _, ev := range evs {
+		if ev.ID == 0 {
+			ev.ID = s.id()
+		}
+		s.events = append(s.events, &event{ev: ev})
+	}
+	return s
+}
+
+// AddRuns adds runs to an event. The event is added if it doesn't exist yet.
+//
+// The runners, hosts and commentators of the runs are added as talent.
+func (s *Server) AddRuns(ev uint, runs ...*gdq.Run) *Server {
+	s.l.Lock()
+	defer s.l.Unlock()
+	e := s.event(ev)
+	for _, run := range runs {
+		if run.ID == 0 {
+			run.ID = s.id()
+		}
+		if run.Order == 0 {
+			run.Order = len(e.runs) + 1
+		}
+		for _, ts := range [][]gdq.Talent{run.Runners, run.Hosts, run.Commentators} {
+			for i := range ts {
+				s.addTalent(&ts[i])
+			}
+		}
+		e.runs = append(e.runs, run)
+	}
+	return s
+}
+
+// AddInterviews adds interviews to an event. The event is added if it
+// doesn't exist yet.
+func (s *Server) AddInterviews(ev uint, ivs ...*gdq.Interview) *Server {
+	s.l.Lock()
+	defer s.l.Unlock()
+	e := s.event(ev)
+	for _, iv := range ivs {
+		if iv.ID == 0 {
+			iv.ID = s.id()
+		}
+		e.interviews = append(e.interviews, iv)
+	}
+	return s
+}
+
+// AddDonations adds donations to an event. The event is added if it
+// doesn't exist yet.
+func (s *Server) AddDonations(ev uint, dons ...*gdq.DonationEntry) *Server {
+	s.l.Lock()
+	defer s.l.Unlock()
+	e := s.event(ev)
+	for _, d := range dons {
+		if d.ID == 0 {
+			d.ID = s.id()
+		}
+		e.donations = append(e.donations, d)
+	}
+	return s
+}
+
+// AddTalent adds runners, hosts or commentators to the tracker.
+func (s *Server) AddTalent(ts ...*gdq.Talent) *Server {
+	s.l.Lock()
+	defer s.l.Unlock()
+	for _, t := range ts {
+		s.addTalent(t)
+	}
+	return s
+}
+
+func (s *Server) addTalent(t *gdq.Talent) {
+	if t.ID == 0 {
+		for id, known := range s.talent {
+			if known.Name == t.Name {
+				t.ID = id
+				break
+			}
+		}
+	}
+	if t.ID == 0 {
+		t.ID = s.id()
+	}
+	s.talent[t.ID] = t
+}
+
+// Fail makes requests to the path fail. The path is relative to the URL of
+// the server and excludes the query string, like "/events/34/runs/".
+//
+// Only one failure can be configured per path. Passing a zero Failure
+// removes it.
+func (s *Server) Fail(path string, f Failure) *Server {
+	s.l.Lock()
+	defer s.l.Unlock()
+	if f == (Failure{}) {
+		delete(s.failures, path)
+		return s
+	}
+	s.failures[path] = &f
+	return s
+}
+
+// Requests returns how many requests were made to the path, including
+// requests that failed.
+func (s *Server) Requests(path string) int {
+	s.l.Lock()
+	defer s.l.Unlock()
+	return s.requests[path]
+}
+
+// id returns the next free ID. The caller must hold the lock.
+func (s *Server) id() uint {
+	id := s.nextID
+	s.nextID++
+	return id
+}
+
+// event returns the event, adding it if it doesn't exist yet. The caller
+// must hold the lock.
+func (s *Server) event(id uint) *event {
+	for _, e := range s.events {
+		if e.ev.ID == id {
+			return e
+		}
+	}
+	e := &event{ev: &gdq.Event{ID: id}}
+	if ev, ok := gdq.GetEventByID(id); ok {
+		e.ev = ev
+	}
+	if id >= s.nextID {
+		s.nextID = id + 1
+	}
+	s.events = append(s.events, e)
+	return e
+}
+
+// Failure describes how a request to the fake tracker fails.
+//
+// A Failure with a Status responds with that status code, and the Detail as
+// the error message. A Failure with a Delay waits that long before
+// responding. When both are set, the delay happens first.
+type Failure struct {
+	Status     int
+	Detail     string
+	RetryAfter time.Duration
+	Delay      time.Duration
+	// Remaining is the number of requests that fail before the path starts
+	// working again, or 0 to keep failing.
+	Remaining int
+}
+
+// Times returns a copy of the failure that only fails the next n requests.
+func (f Failure) Times(n int) Failure {
+	f.Remaining = n
+	return f
+}
+
+// NotFound fails with a 404 Not Found, the way the tracker does for unknown
+// resources.
+func NotFound(detail string) Failure {
+	return Failure{Status: http.StatusNotFound, Detail: detail}
+}
+
+// RateLimited fails with a 429 Too Many Requests, asking the client to come
+// back after retryAfter.
+func RateLimited(retryAfter time.Duration) Failure {
+	return Failure{Status: http.StatusTooManyRequests, Detail: "Request was throttled.", RetryAfter: retryAfter}
+}
+
+// ServerError fails with a 500 Internal Server Error.
+func ServerError() Failure {
+	return Failure{Status: http.StatusInternalServerError}
+}
+
+// Slow waits for d before responding normally, or until the client gives
+// up.
+func Slow(d time.Duration) Failure {
+	return Failure{Delay: d}
+}
+
+// failure returns the failure for the path, if any, and counts the request.
+func (s *Server) failure(path string) *Failure {
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	s.requests[path]++
+	f, ok := s.failures[path]
+	if !ok {
+		return nil
+	}
+	res := *f
+	if f.Remaining > 0 {
+		f.Remaining--
+		if f.Remaining == 0 {
+			delete(s.failures, path)
+		}
+	}
+	return &res
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	if f := s.failure(r.URL.Path); f != nil {
+		if f.Delay > 0 {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(f.Delay):
+			}
+		}
+		if f.Status != 0 {
+			if f.RetryAfter > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(int(f.RetryAfter.Round(time.Second)/time.Second)))
+			}
+			writeError(w, f.Status, f.Detail)
+			return
+		}
+	}
+
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("Method \"%s\" not allowed.", r.Method))
+		return
+	}
+
+	segs := strings.FieldsFunc(r.URL.Path, func(r rune) bool { return r == '/' })
+
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	switch {
+	case len(segs) == 1 && segs[0] == "events":
+		res := make([]any, 0, len(s.events))
+		for _, e := range s.events {
+			res = append(res, toEventRecord(e.ev, false))
+		}
+		s.writePage(w, r, res)
+		return
+	case len(segs) == 2 && segs[0] == "talent":
+		id, err := strconv.ParseUint(segs[1], 10, 64)
+		if t, ok := s.talent[uint(id)]; err == nil && ok {
+			writeJSON(w, talentRecord{Type: "talent", Talent: *t})
+			return
+		}
+	case len(segs) >= 2 && segs[0] == "events":
+		id, err := strconv.ParseUint(segs[1], 10, 64)
+		if err != nil {
+			break
+		}
+		idx := slices.IndexFunc(s.events, func(e *event) bool { return e.ev.ID == uint(id) })
+		if idx == -1 {
+			break
+		}
+		s.serveEvent(w, r, s.events[idx], segs[2:])
+		return
+	}
+
+	writeError(w, http.StatusNotFound, "Not found.")
+}
+
+func (s *Server) serveEvent(w http.ResponseWriter, r *http.Request, e *event, segs []string) {
+	if len(segs) == 0 {
+		writeJSON(w, toEventRecord(e.ev, r.URL.Query().Has("totals")))
+		return
+	}
+	if len(segs) != 1 {
+		writeError(w, http.StatusNotFound, "Not found.")
+		return
+	}
+
+	var res []any
+	switch segs[0] {
+	case "runs":
+		for _, run := range e.runs {
+			res = append(res, toRunRecord(run))
+		}
+	case "interviews":
+		for _, iv := range e.interviews {
+			res = append(res, toInterviewRecord(iv))
+		}
+	case "donations":
+		for _, d := range e.donations {
+			res = append(res, toDonationRecord(d))
+		}
+	case "ads":
+		writeError(w, http.StatusForbidden, "You do not have permission to perform this action.")
+		return
+	default:
+		writeError(w, http.StatusNotFound, "Not found.")
+		return
+	}
+	s.writePage(w, r, res)
+}
+
+// writePage writes the page of results requested through the limit and
+// offset query parameters, like the tracker does.
+func (s *Server) writePage(w http.ResponseWriter, r *http.Request, res []any) {
+	q := r.URL.Query()
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	if limit <= 0 {
+		limit = s.pageSize
+	}
+	offset, _ := strconv.Atoi(q.Get("offset"))
+	offset = max(0, min(offset, len(res)))
+
+	end := len(res)
+	if limit > 0 {
+		end = min(offset+limit, len(res))
+	}
+
+	link := func(offset int) *string {
+		u := url.URL{Scheme: "http", Host: r.Host, Path: r.URL.Path}
+		lq := url.Values{}
+		lq.Set("limit", strconv.Itoa(limit))
+		lq.Set("offset", strconv.Itoa(offset))
+		u.RawQuery = lq.Encode()
+		l := u.String()
+		return &l
+	}
+
+	p := struct {
+		Count    int     `json:"count"`
+		Next     *string `json:"next"`
+		Previous *string `json:"previous"`
+		Results  []any   `json:"results"`
+	}{
+		Count:   len(res),
+		Results: append([]any{}, res[offset:end]...),
+	}
+	if limit > 0 && end < len(res) {
+		p.Next = link(end)
+	}
+	if limit > 0 && offset > 0 {
+		p.Previous = link(max(0, offset-limit))
+	}
+	writeJSON(w, p)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, detail string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if detail == "" {
+		detail = http.StatusText(status)
+	}
+	json.NewEncoder(w).Encode(struct {
+		Detail string `json:"detail"`
+	}{Detail: detail})
+}
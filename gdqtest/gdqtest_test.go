@@ -0,0 +1,196 @@
+package gdqtest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/daenney/gdq/v3"
+	"github.com/daenney/gdq/v3/gdqtest"
+)
+
+func newServer(t *testing.T) (*gdqtest.Server, []*gdq.Run) {
+	t.Helper()
+
+	start := time.Date(2021, 1, 3, 16, 30, 0, 0, time.UTC)
+	runs := []*gdq.Run{
+		{
+			Title:     "Pre-Show",
+			Start:     start,
+			RunTime:   gdq.Duration{Duration: 20 * time.Minute},
+			SetupTime: gdq.Duration{Duration: 6 * time.Minute},
+			Runners:   []gdq.Talent{{Name: "spikevegeta"}},
+			Hosts:     []gdq.Talent{{Name: "briesbe", Pronouns: "she/her"}},
+		},
+		{
+			Title:       "Mirror's Edge",
+			Start:       start.Add(26 * time.Minute),
+			RunTime:     gdq.Duration{Duration: time.Hour},
+			SetupTime:   gdq.Duration{Duration: 11 * time.Minute},
+			Category:    "Inbounds",
+			Platform:    "PC",
+			ReleaseYear: 2009,
+			Runners:     []gdq.Talent{{Name: "Hekigan", Stream: "https://www.twitch.tv/hekigan"}},
+			Hosts:       []gdq.Talent{{Name: "briesbe", Pronouns: "she/her"}},
+		},
+	}
+
+	srv := gdqtest.NewServer(t).
+		AddEvent(&gdq.Event{
+			ID: 34, Short: "AGDQ2021", Name: "Awesome Games Done Quick 2021 Online", Year: 2021,
+			Start: start, Timezone: "America/New_York", Charity: "Prevent Cancer Foundation", Target: gdq.NewMoney(250000000, "USD"), Currency: "USD", DonationsOpen: true,
+			Donations: gdq.Donation{Count: 2, Amount: gdq.NewMoney(3000, "USD")},
+		}).
+		AddRuns(34, runs...).
+		AddInterviews(34, &gdq.Interview{Topic: "Prizes", Interviewers: []string{"Sent"}, Subjects: []string{"Prizes"}, Order: 1, Suborder: 1, Length: gdq.Duration{Duration: 5 * time.Minute}}).
+		AddDonations(34,
+			&gdq.DonationEntry{Donor: "(Anonymous)", Amount: gdq.NewMoney(500, "USD"), Received: start},
+			&gdq.DonationEntry{Donor: "someone", Amount: gdq.NewMoney(2500, "USD"), Received: start.Add(time.Minute), Comment: "Good luck!"},
+		)
+	return srv, runs
+}
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("events", func(t *testing.T) {
+		srv, _ := newServer(t)
+		evs, err := srv.Client().Events(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(evs))
+		assert.Equal(t, "AGDQ2021", evs[0].Short)
+		assert.Equal(t, 2021, evs[0].Year)
+
+		ev, err := srv.Client().Event(ctx, 34)
+		assert.NoError(t, err)
+		assert.Equal(t, gdq.NewMoney(3000, "USD"), ev.Donations.Amount)
+		assert.Equal(t, uint64(2), ev.Donations.Count)
+		assert.True(t, time.Date(2021, 1, 3, 16, 30, 0, 0, time.UTC).Equal(ev.Start))
+		assert.True(t, ev.End.IsZero())
+		assert.Equal(t, "America/New_York", ev.Timezone)
+		assert.Equal(t, "Prevent Cancer Foundation", ev.Charity)
+		assert.Equal(t, gdq.NewMoney(250000000, "USD"), ev.Target)
+		assert.Equal(t, "USD", ev.Currency)
+		assert.True(t, ev.DonationsOpen)
+
+		_, err = srv.Client().Event(ctx, 1)
+		assert.IsError(t, err, gdq.ErrNotFound)
+
+		end, err := srv.Client().EventEnd(ctx, 34)
+		assert.NoError(t, err)
+		assert.True(t, time.Date(2021, 1, 3, 18, 7, 0, 0, time.UTC).Equal(end))
+	})
+	t.Run("runs", func(t *testing.T) {
+		srv, runs := newServer(t)
+		got, err := srv.Client().Runs(ctx, 34)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(got))
+		for i, run := range got {
+			assert.NotZero(t, runs[i].ID)
+			assert.Equal(t, runs[i].ID, run.ID)
+			assert.Equal(t, i+1, run.Order)
+			assert.True(t, runs[i].Start.Equal(run.Start))
+			assert.Equal(t, runs[i].RunTime, run.RunTime)
+			assert.Equal(t, runs[i].RunTime.Add(runs[i].SetupTime), run.Estimate)
+			assert.Equal(t, runs[i].Runners, run.Runners)
+		}
+		assert.Equal(t, 2009, got[1].ReleaseYear)
+		assert.Equal(t, gdq.LocationOnsite, got[1].Onsite)
+		assert.Equal(t, runs[0].Hosts[0].ID, runs[1].Hosts[0].ID)
+	})
+	t.Run("interviews and timeline", func(t *testing.T) {
+		srv, runs := newServer(t)
+		ivs, err := srv.Client().Interviews(ctx, 34)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(ivs))
+		assert.Equal(t, []string{"Sent"}, ivs[0].Interviewers)
+
+		tl, err := srv.Client().Timeline(ctx, 34)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, len(tl.Items))
+		assert.Equal(t, gdq.KindInterview, tl.Items[1].Kind)
+		assert.True(t, runs[0].Start.Add(20*time.Minute).Equal(tl.Items[1].Start))
+	})
+	t.Run("talent", func(t *testing.T) {
+		srv, runs := newServer(t)
+		tal, err := srv.Client().Talent(ctx, runs[1].Runners[0].ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "Hekigan", tal.Name)
+		assert.Equal(t, "https://www.twitch.tv/hekigan", tal.Stream)
+
+		extra := &gdq.Talent{Name: "Kungfufruitcup", Pronouns: "she/her"}
+		srv.AddTalent(extra)
+		tal, err = srv.Client().Talent(ctx, extra.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "she/her", tal.Pronouns)
+	})
+	t.Run("donations", func(t *testing.T) {
+		srv, _ := newServer(t)
+		srv.SetPageSize(1)
+		var dons []*gdq.DonationEntry
+		var total gdq.Money
+		for d, err := range srv.Client().Donations(ctx, 34) {
+			assert.NoError(t, err)
+			dons = append(dons, d)
+			total, err = total.Add(d.Amount)
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, 2, len(dons))
+		assert.Equal(t, "Good luck!", dons[1].Comment)
+		assert.Equal(t, gdq.NewMoney(3000, "USD"), total)
+		assert.Equal(t, 2, srv.Requests("/events/34/donations/"))
+	})
+	t.Run("pagination", func(t *testing.T) {
+		srv, _ := newServer(t)
+		srv.SetPageSize(1)
+		runs, err := srv.Client().Runs(ctx, 34)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(runs))
+		assert.Equal(t, 2, srv.Requests("/events/34/runs/"))
+	})
+}
+
+func TestFailures(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("not found", func(t *testing.T) {
+		srv, _ := newServer(t)
+		srv.Fail("/events/34/runs/", gdqtest.NotFound("Nope."))
+		_, err := srv.Client().Runs(ctx, 34)
+		assert.IsError(t, err, gdq.ErrNotFound)
+		assert.Contains(t, err.Error(), "Nope.")
+	})
+	t.Run("rate limited", func(t *testing.T) {
+		srv, _ := newServer(t)
+		srv.Fail("/events/34/runs/", gdqtest.RateLimited(time.Second))
+		_, err := srv.Client().Runs(ctx, 34)
+		assert.IsError(t, err, gdq.ErrRateLimited)
+		var aerr *gdq.APIError
+		assert.True(t, errors.As(err, &aerr))
+		assert.Equal(t, time.Second, aerr.RetryAfter)
+	})
+	t.Run("server error recovers", func(t *testing.T) {
+		srv, _ := newServer(t)
+		srv.Fail("/events/34/runs/", gdqtest.ServerError().Times(2))
+		c := srv.Client(gdq.WithRetryPolicy(gdq.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}))
+		runs, err := c.Runs(ctx, 34)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(runs))
+		assert.Equal(t, 3, srv.Requests("/events/34/runs/"))
+	})
+	t.Run("slow", func(t *testing.T) {
+		srv, _ := newServer(t)
+		srv.Fail("/events/34/runs/", gdqtest.Slow(time.Second))
+		_, err := srv.Client(gdq.WithTimeout(10*time.Millisecond)).Runs(ctx, 34)
+		assert.IsError(t, err, context.DeadlineExceeded)
+	})
+	t.Run("cleared", func(t *testing.T) {
+		srv, _ := newServer(t)
+		srv.Fail("/events/34/runs/", gdqtest.ServerError())
+		srv.Fail("/events/34/runs/", gdqtest.Failure{})
+		_, err := srv.Client().Runs(ctx, 34)
+		assert.NoError(t, err)
+	})
+}
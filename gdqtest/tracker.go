@@ -0,0 +1,211 @@
+package gdqtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/daenney/gdq/v3"
+)
+
+// The types in this file are the records as the tracker serialises them.
+// They're what the gdq package expects to decode, which doesn't always
+// match how the gdq types serialise themselves.
+
+type eventRecord struct {
+	Type           string       `json:"type"`
+	ID             uint         `json:"id"`
+	Short          string       `json:"short"`
+	Name           string       `json:"name"`
+	Datetime       time.Time    `json:"datetime"`
+	Timezone       string       `json:"timezone"`
+	ReceiverName   string       `json:"receivername"`
+	TargetAmount   json.Number  `json:"targetamount"`
+	PaypalCurrency string       `json:"paypalcurrency"`
+	AllowDonations bool         `json:"allow_donations"`
+	Amount         *json.Number `json:"amount,omitempty"`
+	DonationCount  *uint64      `json:"donation_count,omitempty"`
+}
+
+func toEventRecord(ev *gdq.Event, totals bool) eventRecord {
+	r := eventRecord{
+		Type:           "event",
+		ID:             ev.ID,
+		Short:          ev.Short,
+		Name:           ev.Name,
+		Datetime:       ev.Start,
+		Timezone:       ev.Timezone,
+		ReceiverName:   ev.Charity,
+		TargetAmount:   decimal(ev.Target),
+		PaypalCurrency: ev.Currency,
+		AllowDonations: ev.DonationsOpen,
+	}
+	if r.Datetime.IsZero() {
+		r.Datetime = time.Date(ev.Year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+	if r.Timezone == "" {
+		r.Timezone = "UTC"
+	}
+	if r.PaypalCurrency == "" {
+		r.PaypalCurrency = "USD"
+	}
+	if totals {
+		amount := decimal(ev.Donations.Amount)
+		r.Amount = &amount
+		r.DonationCount = &ev.Donations.Count
+	}
+	return r
+}
+
+type runRecord struct {
+	Type         string          `json:"type"`
+	ID           uint            `json:"id"`
+	Name         string          `json:"name"`
+	DisplayName  string          `json:"display_name"`
+	TwitchName   string          `json:"twitch_name"`
+	Description  string          `json:"description"`
+	Category     string          `json:"category"`
+	Coop         bool            `json:"coop"`
+	Onsite       gdq.Location    `json:"onsite"`
+	Console      string          `json:"console"`
+	ReleaseYear  *int            `json:"release_year"`
+	Runners      []gdq.Talent    `json:"runners"`
+	Hosts        []gdq.Talent    `json:"hosts"`
+	Commentators []gdq.Talent    `json:"commentators"`
+	Starttime    time.Time       `json:"starttime"`
+	Endtime      time.Time       `json:"endtime"`
+	Order        int             `json:"order"`
+	RunTime      string          `json:"run_time"`
+	SetupTime    string          `json:"setup_time"`
+	AnchorTime   *time.Time      `json:"anchor_time"`
+	VideoLinks   []gdq.VideoLink `json:"video_links"`
+	PriorityTag  *string         `json:"priority_tag"`
+	Tags         []string        `json:"tags"`
+}
+
+func toRunRecord(run *gdq.Run) runRecord {
+	r := runRecord{
+		Type:         "speedrun",
+		ID:           run.ID,
+		Name:         run.Title,
+		DisplayName:  run.DisplayName,
+		TwitchName:   run.TwitchName,
+		Description:  run.Description,
+		Category:     run.Category,
+		Coop:         run.Coop,
+		Onsite:       run.Onsite,
+		Console:      run.Platform,
+		Runners:      nonNil(run.Runners),
+		Hosts:        nonNil(run.Hosts),
+		Commentators: nonNil(run.Commentators),
+		Starttime:    run.Start,
+		Endtime:      run.Start.Add(run.RunTime.Duration + run.SetupTime.Duration),
+		Order:        run.Order,
+		RunTime:      duration(run.RunTime.Duration),
+		SetupTime:    duration(run.SetupTime.Duration),
+		VideoLinks:   nonNil(run.VideoLinks),
+		Tags:         nonNil(run.Tags),
+	}
+	if run.Onsite == "" {
+		r.Onsite = gdq.LocationOnsite
+	}
+	if run.ReleaseYear != 0 {
+		r.ReleaseYear = &run.ReleaseYear
+	}
+	if !run.AnchorTime.IsZero() {
+		r.AnchorTime = &run.AnchorTime
+	}
+	if run.PriorityTag != "" {
+		r.PriorityTag = &run.PriorityTag
+	}
+	return r
+}
+
+type interviewRecord struct {
+	Type           string   `json:"type"`
+	ID             uint     `json:"id"`
+	Anchor         *uint    `json:"anchor"`
+	Order          int      `json:"order"`
+	Suborder       int      `json:"suborder"`
+	SocialMedia    bool     `json:"social_media"`
+	Interviewers   string   `json:"interviewers"`
+	Topic          string   `json:"topic"`
+	Public         bool     `json:"public"`
+	Prerecorded    bool     `json:"prerecorded"`
+	Producer       string   `json:"producer"`
+	Length         string   `json:"length"`
+	Subjects       string   `json:"subjects"`
+	CameraOperator string   `json:"camera_operator"`
+	Tags           []string `json:"tags"`
+}
+
+func toInterviewRecord(iv *gdq.Interview) interviewRecord {
+	return interviewRecord{
+		Type:         "interview",
+		ID:           iv.ID,
+		Order:        iv.Order,
+		Suborder:     iv.Suborder,
+		Interviewers: strings.Join(iv.Interviewers, ", "),
+		Topic:        iv.Topic,
+		Public:       iv.Public,
+		Prerecorded:  iv.Prerecorded,
+		Length:       duration(iv.Length.Duration),
+		Subjects:     strings.Join(iv.Subjects, ", "),
+		Tags:         nonNil(iv.Tags),
+	}
+}
+
+type donationRecord struct {
+	Type         string      `json:"type"`
+	ID           uint        `json:"id"`
+	DonorName    string      `json:"donor_name"`
+	Amount       json.Number `json:"amount"`
+	Currency     string      `json:"currency"`
+	TimeReceived time.Time   `json:"timereceived"`
+	Comment      string      `json:"comment"`
+}
+
+func toDonationRecord(d *gdq.DonationEntry) donationRecord {
+	r := donationRecord{
+		Type:         "donation",
+		ID:           d.ID,
+		DonorName:    d.Donor,
+		Amount:       decimal(d.Amount),
+		Currency:     d.Amount.Currency(),
+		TimeReceived: d.Received,
+		Comment:      d.Comment,
+	}
+	if r.Currency == "" {
+		r.Currency = "USD"
+	}
+	return r
+}
+
+type talentRecord struct {
+	Type string `json:"type"`
+	gdq.Talent
+}
+
+// decimal formats m the way the tracker does, as a plain number.
+func decimal(m gdq.Money) json.Number {
+	return json.Number(m.WithCurrency("").String())
+}
+
+// duration formats d the way the tracker does, as H:MM:SS.
+func duration(d time.Duration) string {
+	d = d.Round(time.Second)
+	h := d / time.Hour
+	m := (d % time.Hour) / time.Minute
+	s := (d % time.Minute) / time.Second
+	return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+}
+
+// nonNil returns an empty slice instead of nil, since the tracker always
+// serialises lists as such.
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
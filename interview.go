@@ -0,0 +1,74 @@
+package gdq
+
+import (
+	"strings"
+)
+
+type interviewResult struct {
+	ID           uint     `json:"id"`
+	Order        int      `json:"order"`
+	Suborder     int      `json:"suborder"`
+	Interviewers string   `json:"interviewers"`
+	Subjects     string   `json:"subjects"`
+	Topic        string   `json:"topic"`
+	Public       bool     `json:"public"`
+	Prerecorded  bool     `json:"prerecorded"`
+	Length       Duration `json:"length"`
+	Tags         []string `json:"tags"`
+}
+
+type interviewResp []interviewResult
+
+func (r interviewResp) toInterviews() []*Interview {
+	liv := len(r)
+	if liv == 0 {
+		return nil
+	}
+
+	ivs := make([]*Interview, 0, liv)
+	for _, r := range r {
+		ivs = append(ivs, &Interview{
+			ID:           r.ID,
+			Topic:        r.Topic,
+			Interviewers: splitNames(r.Interviewers),
+			Subjects:     splitNames(r.Subjects),
+			Length:       r.Length,
+			Order:        r.Order,
+			Suborder:     r.Suborder,
+			Prerecorded:  r.Prerecorded,
+			Public:       r.Public,
+			Tags:         r.Tags,
+		})
+	}
+	return ivs
+}
+
+// Interview represents an interview segment at a GDQ.
+//
+// Interviews take place in between runs. Order is the order of the run the
+// interview follows, and Suborder the position of the interview among
+// everything else that happens before the next run starts.
+type Interview struct {
+	ID           uint     `json:"id"`
+	Topic        string   `json:"topic"`
+	Interviewers []string `json:"interviewers"`
+	Subjects     []string `json:"subjects"`
+	Length       Duration `json:"length"`
+	Order        int      `json:"order"`
+	Suborder     int      `json:"suborder"`
+	Prerecorded  bool     `json:"prerecorded"`
+	Public       bool     `json:"public"`
+	Tags         []string `json:"tags"`
+}
+
+// splitNames splits the comma separated list of names the tracker uses for
+// interviewers and subjects.
+func splitNames(s string) []string {
+	names := []string{}
+	for _, n := range strings.Split(s, ",") {
+		if n = strings.TrimSpace(n); n != "" {
+			names = append(names, n)
+		}
+	}
+	return names
+}
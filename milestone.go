@@ -0,0 +1,85 @@
+package gdq
+
+import (
+	"cmp"
+	"slices"
+)
+
+type milestoneResult struct {
+	ID               uint   `json:"id"`
+	Name             string `json:"name"`
+	Start            Money  `json:"start"`
+	Amount           Money  `json:"amount"`
+	Run              uint   `json:"run"`
+	Description      string `json:"description"`
+	ShortDescription string `json:"short_description"`
+	Visible          bool   `json:"visible"`
+}
+
+type milestoneResp []milestoneResult
+
+func (r milestoneResp) toMilestones() []*Milestone {
+	lms := len(r)
+	if lms == 0 {
+		return nil
+	}
+
+	ms := make([]*Milestone, 0, lms)
+	for _, r := range r {
+		ms = append(ms, &Milestone{
+			ID:               r.ID,
+			Name:             r.Name,
+			Start:            r.Start,
+			Amount:           r.Amount,
+			RunID:            r.Run,
+			Description:      r.Description,
+			ShortDescription: r.ShortDescription,
+			Visible:          r.Visible,
+		})
+	}
+	return ms
+}
+
+// Milestone represents a donation total an event is working towards.
+//
+// Start is the donation total at which the milestone starts being shown,
+// and Amount the total at which it is achieved. RunID is the ID of the [Run]
+// the milestone is associated with, or 0 if there isn't one.
+type Milestone struct {
+	ID               uint   `json:"id"`
+	Name             string `json:"name"`
+	Start            Money  `json:"start"`
+	Amount           Money  `json:"amount"`
+	RunID            uint   `json:"run_id"`
+	Description      string `json:"description"`
+	ShortDescription string `json:"short_description"`
+	Visible          bool   `json:"visible"`
+}
+
+// NextMilestone computes the progress towards the milestones for a
+// donation total, like the Amount of an [Event]'s Donations.
+//
+// It returns the milestones that have been achieved sorted by amount, the
+// next milestone to achieve and the amount that still needs to be donated
+// to achieve it. Once every milestone has been achieved, next is nil and
+// remaining is 0. Milestones in a different currency than total are
+// ignored.
+func NextMilestone(milestones []*Milestone, total Money) (achieved []*Milestone, next *Milestone, remaining Money) {
+	sorted := slices.Clone(milestones)
+	slices.SortStableFunc(sorted, func(a, b *Milestone) int {
+		return cmp.Compare(a.Amount.Cents(), b.Amount.Cents())
+	})
+
+	for _, m := range sorted {
+		rem, err := m.Amount.Sub(total)
+		switch {
+		case err != nil:
+			continue
+		case rem.Cents() <= 0:
+			achieved = append(achieved, m)
+			continue
+		}
+		return achieved, m, rem
+	}
+	return achieved, nil, NewMoney(0, total.Currency())
+}
@@ -0,0 +1,50 @@
+package gdq
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestNextMilestone(t *testing.T) {
+	ms := []*Milestone{
+		{Name: "two", Amount: NewMoney(200000, "")},
+		{Name: "one", Amount: NewMoney(100000, "")},
+		{Name: "three", Amount: NewMoney(300000, "")},
+	}
+
+	t.Run("no milestones", func(t *testing.T) {
+		achieved, next, remaining := NextMilestone(nil, usd(150000))
+		assert.Equal(t, 0, len(achieved))
+		assert.Equal(t, nil, next)
+		assert.Equal(t, usd(0), remaining)
+	})
+	t.Run("none achieved", func(t *testing.T) {
+		achieved, next, remaining := NextMilestone(ms, usd(0))
+		assert.Equal(t, 0, len(achieved))
+		assert.Equal(t, "one", next.Name)
+		assert.Equal(t, usd(100000), remaining)
+	})
+	t.Run("some achieved", func(t *testing.T) {
+		achieved, next, remaining := NextMilestone(ms, usd(200000))
+		assert.Equal(t, []*Milestone{ms[1], ms[0]}, achieved)
+		assert.Equal(t, "three", next.Name)
+		assert.Equal(t, usd(100000), remaining)
+	})
+	t.Run("all achieved", func(t *testing.T) {
+		achieved, next, remaining := NextMilestone(ms, usd(350000))
+		assert.Equal(t, 3, len(achieved))
+		assert.Equal(t, nil, next)
+		assert.Equal(t, usd(0), remaining)
+	})
+	t.Run("other currencies are ignored", func(t *testing.T) {
+		achieved, next, remaining := NextMilestone(append([]*Milestone{{Name: "euros", Amount: NewMoney(1, "EUR")}}, ms...), usd(150000))
+		assert.Equal(t, []*Milestone{ms[1]}, achieved)
+		assert.Equal(t, "two", next.Name)
+		assert.Equal(t, usd(50000), remaining)
+	})
+	t.Run("input is not reordered", func(t *testing.T) {
+		NextMilestone(ms, usd(0))
+		assert.Equal(t, "two", ms[0].Name)
+	})
+}
@@ -0,0 +1,251 @@
+package gdq
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// Money is an exact amount of money.
+//
+// The amount is stored in hundredths, the precision the tracker uses, so
+// adding up donations doesn't suffer from the rounding errors a float64
+// does. The currency is an ISO 4217 code like USD. It's empty when the
+// tracker doesn't say which currency an amount is in, in which case it's
+// the Currency of the [Event].
+//
+// Money marshals to a JSON string like "25.50 USD". When unmarshalling it
+// also accepts plain numbers, numeric strings and null, since that's what
+// the tracker returns depending on the endpoint. Amounts with more than 2
+// decimals are rounded to the nearest hundredth.
+//
+// The zero value is 0 in no particular currency.
+type Money struct {
+	cents    int64
+	currency string
+}
+
+// NewMoney returns cents hundredths of currency.
+func NewMoney(cents int64, currency string) Money {
+	return Money{cents: cents, currency: currency}
+}
+
+// ParseMoney parses an amount like "25.50", "25.50 USD" or "2.55e1".
+// Amounts with more than 2 decimals are rounded to the nearest hundredth,
+// with halves rounded away from zero.
+func ParseMoney(s string) (Money, error) {
+	num, cur, _ := strings.Cut(strings.TrimSpace(s), " ")
+	cents, err := parseCents(num)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{cents: cents, currency: strings.TrimSpace(cur)}, nil
+}
+
+var amountPattern = regexp.MustCompile(`^([+-]?)([0-9]+)(?:\.([0-9]*))?(?:[eE]([+-]?[0-9]+))?$`)
+
+// parseCents parses a decimal number into hundredths, rounding it if it has
+// more than 2 decimals.
+func parseCents(s string) (int64, error) {
+	m := amountPattern.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	sign, digits := m[1], m[2]+m[3]
+	exp := 0
+	if m[4] != "" {
+		var err error
+		if exp, err = strconv.Atoi(m[4]); err != nil {
+			return 0, fmt.Errorf("invalid amount %q: %w", s, err)
+		}
+	}
+
+	// The amount is digits * 10^shift hundredths.
+	shift := exp - len(m[3]) + 2
+	digits = strings.TrimLeft(digits, "0")
+	round := false
+	switch {
+	case digits == "":
+		return 0, nil
+	case shift >= 0:
+		if len(digits)+shift > 19 {
+			return 0, fmt.Errorf("invalid amount %q: out of range", s)
+		}
+		digits += strings.Repeat("0", shift)
+	default:
+		cut := len(digits) + shift
+		if cut < 0 {
+			// Less than a tenth of a hundredth rounds to 0.
+			digits = "0"
+			break
+		}
+		round = digits[cut] >= '5'
+		digits = digits[:cut]
+		if digits == "" {
+			digits = "0"
+		}
+	}
+
+	cents, err := strconv.ParseInt(sign+digits, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q: %w", s, err)
+	}
+	if round {
+		if sign == "-" {
+			cents--
+		} else {
+			cents++
+		}
+	}
+	return cents, nil
+}
+
+// Cents returns the amount in hundredths.
+func (m Money) Cents() int64 {
+	return m.cents
+}
+
+// Currency returns the ISO 4217 code of the currency, if known.
+func (m Money) Currency() string {
+	return m.currency
+}
+
+// WithCurrency returns the same amount in currency. It doesn't convert
+// between currencies.
+func (m Money) WithCurrency(currency string) Money {
+	m.currency = currency
+	return m
+}
+
+// IsZero returns whether the amount is 0.
+func (m Money) IsZero() bool {
+	return m.cents == 0
+}
+
+// SameCurrency returns whether m and o can be added, subtracted and
+// compared. That's the case when they're in the same currency, or when
+// either of them has no currency.
+func (m Money) SameCurrency(o Money) bool {
+	return m.currency == "" || o.currency == "" || m.currency == o.currency
+}
+
+// Add returns m+o.
+//
+// An amount without a currency takes on the currency of the other. Adding
+// amounts in different currencies returns an error matching
+// [ErrMixedCurrencies].
+func (m Money) Add(o Money) (Money, error) {
+	cur, err := m.common(o)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{cents: m.cents + o.cents, currency: cur}, nil
+}
+
+// Sub returns m-o. It treats currencies the same way as [Money.Add].
+func (m Money) Sub(o Money) (Money, error) {
+	cur, err := m.common(o)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{cents: m.cents - o.cents, currency: cur}, nil
+}
+
+// Cmp compares m and o, returning -1, 0 or +1 like [cmp.Compare]. It
+// treats currencies the same way as [Money.Add].
+func (m Money) Cmp(o Money) (int, error) {
+	if _, err := m.common(o); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.cents < o.cents:
+		return -1, nil
+	case m.cents > o.cents:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+func (m Money) common(o Money) (string, error) {
+	switch {
+	case !m.SameCurrency(o):
+		return "", fmt.Errorf("%w: %s and %s", ErrMixedCurrencies, m.currency, o.currency)
+	case m.currency == "":
+		return o.currency, nil
+	default:
+		return m.currency, nil
+	}
+}
+
+// Float64 returns the amount as a float64, for when exactness no longer
+// matters.
+func (m Money) Float64() float64 {
+	return float64(m.cents) / 100
+}
+
+// String returns the amount followed by the currency, like "25.50 USD".
+func (m Money) String() string {
+	sign := ""
+	cents := m.cents
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	s := fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+	if m.currency != "" {
+		s += " " + m.currency
+	}
+	return s
+}
+
+// Format formats the amount the way it's written in the language, like
+// "$ 1,234.50" for American English or "$ 1.234,50" for German.
+//
+// Amounts without a currency are formatted as a number.
+func (m Money) Format(tag language.Tag) string {
+	p := message.NewPrinter(tag)
+	unit, err := currency.ParseISO(m.currency)
+	if err != nil {
+		return p.Sprintf("%.2f", m.Float64())
+	}
+	return p.Sprint(currency.Symbol(unit.Amount(m.Float64())))
+}
+
+// MarshalJSON marshals the amount to a JSON string.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON unmarshals an amount from a JSON string, number or null.
+func (m *Money) UnmarshalJSON(b []byte) error {
+	s := string(b)
+	switch {
+	case s == "null":
+		*m = Money{}
+		return nil
+	case strings.HasPrefix(s, `"`):
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		v, err := ParseMoney(s)
+		if err != nil {
+			return err
+		}
+		*m = v
+		return nil
+	default:
+		cents, err := parseCents(s)
+		if err != nil {
+			return err
+		}
+		*m = Money{cents: cents}
+		return nil
+	}
+}
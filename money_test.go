@@ -0,0 +1,121 @@
+package gdq
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"golang.org/x/text/language"
+)
+
+func usd(cents int64) Money {
+	return NewMoney(cents, "USD")
+}
+
+func TestParseMoney(t *testing.T) {
+	for in, out := range map[string]Money{
+		"0":           NewMoney(0, ""),
+		"25":          NewMoney(2500, ""),
+		"25.5":        NewMoney(2550, ""),
+		"25.50 USD":   usd(2550),
+		"-0.05":       NewMoney(-5, ""),
+		"1234.500000": NewMoney(123450, ""),
+		"1.005":       NewMoney(101, ""),
+		"1.004999":    NewMoney(100, ""),
+		"-1.005":      NewMoney(-101, ""),
+		"0.001":       NewMoney(0, ""),
+		"1e3":         NewMoney(100000, ""),
+		"2.55E+1 EUR": NewMoney(2550, "EUR"),
+		"25e-1":       NewMoney(250, ""),
+		"5e-3":        NewMoney(1, ""),
+		"0e999999":    NewMoney(0, ""),
+		"0.0006":      NewMoney(0, ""),
+		"0.0001":      NewMoney(0, ""),
+		"-0.0009":     NewMoney(0, ""),
+		"0.0051":      NewMoney(1, ""),
+		"1e-5":        NewMoney(0, ""),
+		"9e-999999":   NewMoney(0, ""),
+	} {
+		m, err := ParseMoney(in)
+		assert.NoError(t, err, in)
+		assert.Equal(t, out, m, in)
+	}
+
+	for _, in := range []string{"", "-", ".5", "abc", "1.-5", "1e", "1/2", "0x10", "99999999999999999999", "1e99999999999999999999", "1e17"} {
+		_, err := ParseMoney(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	var v struct {
+		Number Money `json:"number"`
+		String Money `json:"string"`
+		Null   Money `json:"null"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(`{"number":2500000.10,"string":"0.30","null":null}`), &v))
+	assert.Equal(t, NewMoney(250000010, ""), v.Number)
+	assert.Equal(t, NewMoney(30, ""), v.String)
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"number":2.5e6,"string":"0.30000000000000004"}`), &v))
+	assert.Equal(t, NewMoney(250000000, ""), v.Number)
+	assert.Equal(t, NewMoney(30, ""), v.String)
+	assert.Equal(t, Money{}, v.Null)
+
+	data, err := json.Marshal(usd(-1234))
+	assert.NoError(t, err)
+	assert.Equal(t, `"-12.34 USD"`, string(data))
+
+	var m Money
+	assert.NoError(t, json.Unmarshal(data, &m))
+	assert.Equal(t, usd(-1234), m)
+
+	assert.Error(t, json.Unmarshal([]byte(`true`), &m))
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	var (
+		total Money
+		err   error
+	)
+	for range 10 {
+		total, err = total.Add(NewMoney(10, ""))
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, NewMoney(100, ""), total)
+	assert.Equal(t, "1.00", total.String())
+
+	total, err = total.Add(usd(20))
+	assert.NoError(t, err)
+	assert.Equal(t, usd(120), total)
+	diff, err := NewMoney(40, "").Sub(total)
+	assert.NoError(t, err)
+	assert.Equal(t, usd(-80), diff)
+	assert.Equal(t, 1.2, total.Float64())
+
+	for want, pair := range map[int][2]Money{
+		-1: {usd(1), usd(2)},
+		0:  {usd(2), NewMoney(2, "")},
+		1:  {usd(3), usd(2)},
+	} {
+		c, err := pair[0].Cmp(pair[1])
+		assert.NoError(t, err)
+		assert.Equal(t, want, c)
+	}
+
+	eur := NewMoney(1, "EUR")
+	assert.False(t, usd(1).SameCurrency(eur))
+	assert.True(t, NewMoney(1, "").SameCurrency(eur))
+	_, err = usd(1).Add(eur)
+	assert.IsError(t, err, ErrMixedCurrencies)
+	_, err = usd(1).Sub(eur)
+	assert.IsError(t, err, ErrMixedCurrencies)
+	_, err = usd(1).Cmp(eur)
+	assert.IsError(t, err, ErrMixedCurrencies)
+}
+
+func TestMoneyFormat(t *testing.T) {
+	assert.Equal(t, "$ 1,234.50", usd(123450).Format(language.AmericanEnglish))
+	assert.Equal(t, "€ 1.234,50", NewMoney(123450, "EUR").Format(language.German))
+	assert.Equal(t, "1,234.50", NewMoney(123450, "").Format(language.English))
+}
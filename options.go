@@ -0,0 +1,136 @@
+package gdq
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Option configures a [Client].
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used to talk to the tracker.
+//
+// It defaults to [http.DefaultClient], which is also used when client is
+// nil.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Client) {
+		if client == nil {
+			client = http.DefaultClient
+		}
+		c.c = client
+	}
+}
+
+// WithBaseURL sets the URL of the tracker's v2 API.
+//
+// This can be used to point the client at a mirror or a stand-in for the
+// tracker. It defaults to the GDQ tracker.
+func WithBaseURL(url string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(url, "/")
+	}
+}
+
+// WithTimeout sets how long a single request to the tracker may take.
+//
+// When a request is retried, every attempt gets the full timeout. A timeout
+// of 0 disables it. It defaults to 1 minute.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = d
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+//
+// Set this to something GDQ staff can contact you at in case your usage
+// causes a problem. If omitted, Go's default user agent is used.
+func WithUserAgent(ua string) Option {
+	return func(c *Client) {
+		c.userAgent = ua
+	}
+}
+
+// WithRetryPolicy configures how the client retries failed requests.
+//
+// By default requests aren't retried.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = p
+	}
+}
+
+// WithCache enables caching of responses from the tracker.
+//
+// Responses are reused without contacting the tracker for ttl. After that
+// the client makes a conditional request, and the tracker only sends the
+// response again if it has changed. A ttl of 0 means every request is
+// conditional.
+//
+// Donations are never cached, since there can be tens of thousands of them
+// for a single event.
+func WithCache(ttl time.Duration) Option {
+	return func(c *Client) {
+		c.ensureCache()
+		c.cache.ttl = ttl
+	}
+}
+
+// WithMaxStale sets how old a cached response may be to still be used when
+// the tracker can't be reached. It's only used when caching is enabled.
+//
+// When retrieving a response fails because of a network error, a timeout
+// or a 5xx or 429 status code, the cached response is returned instead,
+// regardless of its ttl. A maxAge of 0, the default, means any cached
+// response will do.
+func WithMaxStale(maxAge time.Duration) Option {
+	return func(c *Client) {
+		c.maxAge = maxAge
+	}
+}
+
+// WithDiskCache stores cached responses in dir, in addition to keeping them
+// in memory. It enables caching if it isn't already.
+//
+// This lets cached responses survive restarts, which is what makes
+// [WithOffline] useful. [DefaultCacheDir] returns a suitable directory.
+func WithDiskCache(dir string) Option {
+	return func(c *Client) {
+		c.ensureCache()
+		c.cache.dir = dir
+	}
+}
+
+// WithOffline stops the client from contacting the tracker. It enables
+// caching if it isn't already.
+//
+// Every request is served from the cache instead, regardless of its ttl, as
+// long as the cached response isn't older than maxAge. A maxAge of 0 means
+// any cached response will do. Requests that can't be served from the cache
+// fail with [ErrNotCached].
+func WithOffline(maxAge time.Duration) Option {
+	return func(c *Client) {
+		c.ensureCache()
+		c.offline = true
+		c.maxAge = maxAge
+	}
+}
+
+// WithCacheTTL sets the ttl for a kind of resource, overriding the one
+// passed to [WithCache]. It enables caching if it isn't already.
+//
+// The resource is the kind of data an endpoint returns, like "events",
+// "runs", "interviews", "bids" or "talent".
+func WithCacheTTL(resource string, ttl time.Duration) Option {
+	return func(c *Client) {
+		c.ensureCache()
+		c.cache.ttls[resource] = ttl
+	}
+}
+
+func (c *Client) ensureCache() {
+	if c.cache == nil {
+		c.cache = newResponseCache()
+	}
+}
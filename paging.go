@@ -0,0 +1,95 @@
+package gdq
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// page is a single page of results from a list endpoint of the tracker.
+type page[T any] struct {
+	Count    int    `json:"count"`
+	Next     string `json:"next"`
+	Previous string `json:"previous"`
+	Results  []T    `json:"results"`
+}
+
+// pages returns an iterator over every page of a list endpoint.
+//
+// It follows the next link of every page until the tracker stops returning
+// one. Iteration ends after the first error. A next link to a page that's
+// already been retrieved results in a [PageLoopError].
+func pages[T any](ctx context.Context, c *Client, endpoint string) iter.Seq2[*page[T], error] {
+	return func(yield func(*page[T], error) bool) {
+		seen := map[string]bool{}
+		for next := endpoint; next != ""; {
+			if seen[next] {
+				yield(nil, &PageLoopError{Endpoint: endpoint, Next: next})
+				return
+			}
+			seen[next] = true
+
+			p, err := fromJSON[page[T]](ctx, c, next)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(p, nil) {
+				return
+			}
+			next = p.Next
+		}
+	}
+}
+
+// PageLoopError is returned when the tracker links to a page of results
+// that's already been retrieved, which would otherwise result in retrieving
+// the same pages forever.
+type PageLoopError struct {
+	Endpoint string
+	Next     string
+}
+
+func (e *PageLoopError) Error() string {
+	return fmt.Sprintf("tracker linked back to already retrieved page %s while retrieving %s", e.Next, e.Endpoint)
+}
+
+// CountMismatchError is returned when the number of results retrieved from
+// a list endpoint doesn't match the count reported by the tracker.
+//
+// This typically happens when the data changes while it's being retrieved,
+// in which case trying again usually helps.
+type CountMismatchError struct {
+	Endpoint string
+	Count    int
+	Fetched  int
+}
+
+func (e *CountMismatchError) Error() string {
+	return fmt.Sprintf("tracker reported %d results for %s but retrieved %d", e.Count, e.Endpoint, e.Fetched)
+}
+
+// fetchAll retrieves the results from every page of a list endpoint.
+//
+// Once the last page has been retrieved, the number of results is checked
+// against the count the tracker reported on that page. If they disagree a
+// [CountMismatchError] is returned.
+func fetchAll[T any](ctx context.Context, c *Client, endpoint string) ([]T, error) {
+	var (
+		results []T
+		count   int
+	)
+	for p, err := range pages[T](ctx, c, endpoint) {
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, p.Results...)
+		count = p.Count
+	}
+
+	if count != len(results) {
+		return nil, &CountMismatchError{Endpoint: endpoint, Count: count, Fetched: len(results)}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,70 @@
+package gdq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestFetchAll(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pages/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "":
+			fmt.Fprintf(w, `{"count":3,"next":"http://%s/pages/?page=2","previous":null,"results":[1,2]}`, r.Host)
+		case "2":
+			fmt.Fprintf(w, `{"count":3,"next":null,"previous":"http://%s/pages/","results":[3]}`, r.Host)
+		}
+	})
+	mux.HandleFunc("/short/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"count":3,"next":null,"previous":null,"results":[1,2]}`)
+	})
+	mux.HandleFunc("/broken/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "":
+			fmt.Fprintf(w, `{"count":3,"next":"http://%s/broken/?page=2","previous":null,"results":[1,2]}`, r.Host)
+		case "2":
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	})
+	mux.HandleFunc("/loop/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "":
+			fmt.Fprintf(w, `{"count":4,"next":"http://%s/loop/?page=2","previous":null,"results":[1,2]}`, r.Host)
+		case "2":
+			fmt.Fprintf(w, `{"count":4,"next":"http://%s/loop/?page=2","previous":null,"results":[3,4]}`, r.Host)
+		}
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	t.Run("follows next", func(t *testing.T) {
+		res, err := fetchAll[int](context.Background(), New(), ts.URL+"/pages/")
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3}, res)
+	})
+	t.Run("count mismatch", func(t *testing.T) {
+		_, err := fetchAll[int](context.Background(), New(), ts.URL+"/short/")
+		var cerr *CountMismatchError
+		assert.True(t, errors.As(err, &cerr))
+		assert.Equal(t, 3, cerr.Count)
+		assert.Equal(t, 2, cerr.Fetched)
+		assert.Equal(t, ts.URL+"/short/", cerr.Endpoint)
+	})
+	t.Run("next links back", func(t *testing.T) {
+		_, err := fetchAll[int](context.Background(), New(), ts.URL+"/loop/")
+		var lerr *PageLoopError
+		assert.True(t, errors.As(err, &lerr))
+		assert.Equal(t, ts.URL+"/loop/?page=2", lerr.Next)
+	})
+	t.Run("failing page", func(t *testing.T) {
+		_, err := fetchAll[int](context.Background(), New(), ts.URL+"/broken/")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), http.StatusText(http.StatusBadGateway))
+	})
+}
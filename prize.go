@@ -0,0 +1,121 @@
+package gdq
+
+import (
+	"time"
+)
+
+type prizeResult struct {
+	ID               uint      `json:"id"`
+	Name             string    `json:"name"`
+	Description      string    `json:"description"`
+	ShortDescription string    `json:"shortdescription"`
+	Image            string    `json:"image"`
+	AltImage         string    `json:"altimage"`
+	Provider         string    `json:"provider"`
+	MinimumBid       Money     `json:"minimumbid"`
+	NumWinners       uint      `json:"numwinners"`
+	StartRun         uint      `json:"startrun"`
+	EndRun           uint      `json:"endrun"`
+	StartTime        time.Time `json:"starttime"`
+	EndTime          time.Time `json:"endtime"`
+}
+
+type prizeResp []prizeResult
+
+func (r prizeResp) toPrizes() []*Prize {
+	lpr := len(r)
+	if lpr == 0 {
+		return nil
+	}
+
+	prizes := make([]*Prize, 0, lpr)
+	for _, r := range r {
+		prizes = append(prizes, &Prize{
+			ID:               r.ID,
+			Name:             r.Name,
+			Description:      r.Description,
+			ShortDescription: r.ShortDescription,
+			Image:            r.Image,
+			AltImage:         r.AltImage,
+			Provider:         r.Provider,
+			MinimumBid:       r.MinimumBid,
+			Winners:          r.NumWinners,
+			StartRunID:       r.StartRun,
+			EndRunID:         r.EndRun,
+			StartTime:        r.StartTime,
+			EndTime:          r.EndTime,
+		})
+	}
+	return prizes
+}
+
+// Prize represents a prize donors can win at a GDQ.
+//
+// A prize can be won by donating at least the MinimumBid while it's open for
+// entry. That window is either tied to runs, in which case StartRunID and
+// EndRunID are set to the IDs of those runs, or to StartTime and EndTime. A
+// prize without either is open for the whole event.
+type Prize struct {
+	ID               uint      `json:"id"`
+	Name             string    `json:"name"`
+	Description      string    `json:"description"`
+	ShortDescription string    `json:"short_description"`
+	Image            string    `json:"image"`
+	AltImage         string    `json:"alt_image"`
+	Provider         string    `json:"provider"`
+	MinimumBid       Money     `json:"minimum_bid"`
+	Winners          uint      `json:"winners"`
+	StartRunID       uint      `json:"start_run_id"`
+	EndRunID         uint      `json:"end_run_id"`
+	StartTime        time.Time `json:"start_time"`
+	EndTime          time.Time `json:"end_time"`
+}
+
+// Window returns when the prize is open for entry.
+//
+// When the window is tied to runs, they're looked up in the schedule. The
+// prize opens when the start run starts and closes when the end run is
+// estimated to finish. A zero start or end means the window is open on that
+// side. If a run can't be found on the schedule, ok is false.
+func (p *Prize) Window(s *Schedule) (start time.Time, end time.Time, ok bool) {
+	start, end = p.StartTime, p.EndTime
+
+	if p.StartRunID != 0 {
+		run := s.RunByID(p.StartRunID)
+		if run == nil {
+			return time.Time{}, time.Time{}, false
+		}
+		start = run.Start
+	}
+	if p.EndRunID != 0 {
+		run := s.RunByID(p.EndRunID)
+		if run == nil {
+			return time.Time{}, time.Time{}, false
+		}
+		end = run.End()
+	}
+
+	return start, end, true
+}
+
+// AvailablePrizes returns the prizes that are open for entry at t.
+//
+// Prizes whose window can't be determined from the schedule are never
+// considered to be open.
+func AvailablePrizes(prizes []*Prize, s *Schedule, t time.Time) []*Prize {
+	var res []*Prize
+	for _, p := range prizes {
+		start, end, ok := p.Window(s)
+		if !ok {
+			continue
+		}
+		if !start.IsZero() && t.Before(start) {
+			continue
+		}
+		if !end.IsZero() && !t.Before(end) {
+			continue
+		}
+		res = append(res, p)
+	}
+	return res
+}
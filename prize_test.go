@@ -0,0 +1,40 @@
+package gdq
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestAvailablePrizes(t *testing.T) {
+	start := time.Date(2021, 1, 3, 12, 0, 0, 0, time.UTC)
+	s := NewScheduleFrom([]*Run{
+		{ID: 1, Start: start, Estimate: Duration{time.Hour}},
+		{ID: 2, Start: start.Add(time.Hour), Estimate: Duration{time.Hour}},
+	})
+
+	byRun := &Prize{Name: "by run", StartRunID: 2, EndRunID: 2}
+	byTime := &Prize{Name: "by time", StartTime: start.Add(30 * time.Minute), EndTime: start.Add(90 * time.Minute)}
+	openEnded := &Prize{Name: "open ended", StartRunID: 1}
+	unknown := &Prize{Name: "unknown", StartRunID: 3}
+	always := &Prize{Name: "always"}
+	prizes := []*Prize{byRun, byTime, openEnded, unknown, always}
+
+	t.Run("before the event", func(t *testing.T) {
+		assert.Equal(t, []*Prize{always}, AvailablePrizes(prizes, s, start.Add(-time.Minute)))
+	})
+	t.Run("during the first run", func(t *testing.T) {
+		assert.Equal(t, []*Prize{openEnded, always}, AvailablePrizes(prizes, s, start))
+		assert.Equal(t, []*Prize{byTime, openEnded, always}, AvailablePrizes(prizes, s, start.Add(30*time.Minute)))
+	})
+	t.Run("during the second run", func(t *testing.T) {
+		assert.Equal(t, []*Prize{byRun, byTime, openEnded, always}, AvailablePrizes(prizes, s, start.Add(time.Hour)))
+	})
+	t.Run("after the event", func(t *testing.T) {
+		assert.Equal(t, []*Prize{openEnded, always}, AvailablePrizes(prizes, s, start.Add(2*time.Hour)))
+	})
+	t.Run("without a schedule", func(t *testing.T) {
+		assert.Equal(t, []*Prize{byTime, always}, AvailablePrizes(prizes, nil, start.Add(time.Hour)))
+	})
+}
@@ -0,0 +1,269 @@
+package gdq
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// queryFields are the fields a query can match on, and how to get their
+// values from a run.
+var queryFields = map[string]func(*Run) []string{
+	"title":       func(r *Run) []string { return []string{r.Title} },
+	"runner":      func(r *Run) []string { return talentNames(r.Runners) },
+	"host":        func(r *Run) []string { return talentNames(r.Hosts) },
+	"commentator": func(r *Run) []string { return talentNames(r.Commentators) },
+	"category":    func(r *Run) []string { return []string{r.Category} },
+	"platform":    func(r *Run) []string { return []string{r.Platform} },
+	"tag":         func(r *Run) []string { return r.Tags },
+}
+
+// ParseQuery parses a query and returns a function that reports whether a
+// run matches it. Use it with [Schedule.Filter].
+//
+// A query is made up of terms like runner:foo, which match runs where the
+// field contains the value. Like the other filters, matching is case
+// insensitive and ignores punctuation and diacritics, so title:foo matches
+// the same runs as [Schedule.ForTitle] does. Values with spaces in
+// them can be quoted, like title:"super mario". A term without a field
+// matches the title. The fields are title, runner, host, commentator,
+// category, platform and tag.
+//
+// Terms can be combined with AND, OR and NOT, and grouped with parentheses:
+//
+//	runner:foo OR (host:bar AND platform:snes) AND NOT category:any%
+//
+// NOT binds tighter than AND, which binds tighter than OR. Terms next to
+// each other without an operator are combined with AND. The operators have
+// to be written in upper case, so they can't be confused with titles.
+func ParseQuery(query string) (func(*Run) bool, error) {
+	toks, err := lexQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(toks) == 0 {
+		return nil, fmt.Errorf("query: empty query")
+	}
+
+	p := &queryParser{toks: toks}
+	f, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok != nil {
+		return nil, fmt.Errorf("query: unexpected %q at position %d", tok.text, tok.pos)
+	}
+	return f, nil
+}
+
+type tokenKind int
+
+const (
+	tokTerm tokenKind = iota
+	tokAnd
+	tokOr
+	tokNot
+	tokOpen
+	tokClose
+)
+
+type token struct {
+	kind  tokenKind
+	pos   int
+	text  string
+	field string
+	value string
+}
+
+// lexQuery splits a query into tokens.
+func lexQuery(query string) ([]token, error) {
+	var toks []token
+	rs := []rune(query)
+	for i := 0; i < len(rs); {
+		switch {
+		case unicode.IsSpace(rs[i]):
+			i++
+			continue
+		case rs[i] == '(':
+			toks = append(toks, token{kind: tokOpen, pos: i, text: "("})
+			i++
+			continue
+		case rs[i] == ')':
+			toks = append(toks, token{kind: tokClose, pos: i, text: ")"})
+			i++
+			continue
+		}
+
+		start := i
+		var b strings.Builder
+		field := ""
+		for i < len(rs) && !unicode.IsSpace(rs[i]) && rs[i] != '(' && rs[i] != ')' {
+			switch {
+			case rs[i] == '"':
+				end := slices.Index(rs[i+1:], '"')
+				if end == -1 {
+					return nil, fmt.Errorf("query: unterminated quote at position %d", i)
+				}
+				b.WriteString(string(rs[i+1 : i+1+end]))
+				i += end + 2
+			case rs[i] == ':' && field == "" && b.Len() > 0:
+				field = b.String()
+				b.Reset()
+				i++
+			default:
+				b.WriteRune(rs[i])
+				i++
+			}
+		}
+
+		tok := token{kind: tokTerm, pos: start, text: string(rs[start:i]), field: field, value: b.String()}
+		if field == "" {
+			switch tok.text {
+			case "AND":
+				tok.kind = tokAnd
+			case "OR":
+				tok.kind = tokOr
+			case "NOT":
+				tok.kind = tokNot
+			}
+		}
+		toks = append(toks, tok)
+	}
+	return toks, nil
+}
+
+type queryParser struct {
+	toks []token
+	i    int
+}
+
+func (p *queryParser) peek() *token {
+	if p.i >= len(p.toks) {
+		return nil
+	}
+	return &p.toks[p.i]
+}
+
+// or parses terms separated by OR.
+func (p *queryParser) or() (func(*Run) bool, error) {
+	fs, err := p.list(tokOr, p.and)
+	if err != nil {
+		return nil, err
+	}
+	if len(fs) == 1 {
+		return fs[0], nil
+	}
+	return func(r *Run) bool {
+		return slices.ContainsFunc(fs, func(f func(*Run) bool) bool { return f(r) })
+	}, nil
+}
+
+// and parses terms separated by AND, or nothing at all.
+func (p *queryParser) and() (func(*Run) bool, error) {
+	fs, err := p.list(tokAnd, p.not)
+	if err != nil {
+		return nil, err
+	}
+	if len(fs) == 1 {
+		return fs[0], nil
+	}
+	return func(r *Run) bool {
+		return !slices.ContainsFunc(fs, func(f func(*Run) bool) bool { return !f(r) })
+	}, nil
+}
+
+// list parses one or more operands separated by the operator. For AND the
+// operator is optional.
+func (p *queryParser) list(op tokenKind, operand func() (func(*Run) bool, error)) ([]func(*Run) bool, error) {
+	var fs []func(*Run) bool
+	for {
+		f, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		fs = append(fs, f)
+
+		tok := p.peek()
+		switch {
+		case tok == nil:
+			return fs, nil
+		case tok.kind == op:
+			p.i++
+		case op == tokAnd && (tok.kind == tokTerm || tok.kind == tokNot || tok.kind == tokOpen):
+			// Operands next to each other are combined with AND.
+		default:
+			return fs, nil
+		}
+	}
+}
+
+// not parses an optionally negated operand.
+func (p *queryParser) not() (func(*Run) bool, error) {
+	tok := p.peek()
+	if tok != nil && tok.kind == tokNot {
+		p.i++
+		f, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		return func(r *Run) bool { return !f(r) }, nil
+	}
+	return p.operand()
+}
+
+// operand parses a term or a parenthesised query.
+func (p *queryParser) operand() (func(*Run) bool, error) {
+	tok := p.peek()
+	if tok == nil {
+		return nil, fmt.Errorf("query: unexpected end of query")
+	}
+	p.i++
+
+	switch tok.kind {
+	case tokOpen:
+		f, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if c := p.peek(); c == nil || c.kind != tokClose {
+			return nil, fmt.Errorf("query: missing ) for ( at position %d", tok.pos)
+		}
+		p.i++
+		return f, nil
+	case tokTerm:
+		return compileTerm(tok)
+	default:
+		return nil, fmt.Errorf("query: unexpected %q at position %d", tok.text, tok.pos)
+	}
+}
+
+// compileTerm returns a function that matches runs against a term.
+func compileTerm(tok *token) (func(*Run) bool, error) {
+	field := strings.ToLower(tok.field)
+	if field == "" {
+		field = "title"
+	}
+	values, ok := queryFields[field]
+	if !ok {
+		return nil, fmt.Errorf("query: unknown field %q at position %d", tok.field, tok.pos)
+	}
+
+	match := normalised(tok.value)
+	if match == "" {
+		return nil, fmt.Errorf("query: missing value for %q at position %d", tok.text, tok.pos)
+	}
+	return func(r *Run) bool {
+		return slices.ContainsFunc(values(r), func(v string) bool {
+			return strings.Contains(normalised(v), match)
+		})
+	}, nil
+}
+
+func talentNames(ts []Talent) []string {
+	names := make([]string, 0, len(ts))
+	for _, t := range ts {
+		names = append(names, t.Name)
+	}
+	return names
+}
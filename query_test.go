@@ -0,0 +1,87 @@
+package gdq
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestParseQuery(t *testing.T) {
+	runs := []*Run{
+		{Title: "Super Mario 64", DisplayName: "SM64 Chrono", Runners: []Talent{{Name: "foo"}}, Hosts: []Talent{{Name: "bar"}}, Platform: "N64", Category: "120 Star"},
+		{Title: "Super Metroid", Runners: []Talent{{Name: "zoast"}}, Hosts: []Talent{{Name: "bar"}}, Platform: "SNES", Category: "Any%"},
+		{Title: "Chrono Trigger", Runners: []Talent{{Name: "puwexil"}}, Hosts: []Talent{{Name: "bar"}}, Platform: "SNES", Category: "Glitchless"},
+		{Title: "Celeste", Runners: []Talent{{Name: "foo"}}, Commentators: []Talent{{Name: "Pokémon"}}, Platform: "PC", Category: "Any%", Tags: []string{"kaizo"}},
+	}
+
+	for query, want := range map[string][]string{
+		"runner:foo":                 {"Super Mario 64", "Celeste"},
+		"RUNNER:FOO":                 {"Super Mario 64", "Celeste"},
+		"mario":                      {"Super Mario 64"},
+		"title:chrono":               {"Chrono Trigger"},
+		`title:"super m"`:            {"Super Mario 64", "Super Metroid"},
+		"runner:foo OR runner:zoast": {"Super Mario 64", "Super Metroid", "Celeste"},
+		"host:bar platform:snes":     {"Super Metroid", "Chrono Trigger"},
+		"NOT platform:snes":          {"Super Mario 64", "Celeste"},
+		"NOT NOT platform:snes":      {"Super Metroid", "Chrono Trigger"},
+		"commentator:pokemon":        {"Celeste"},
+		"tag:kaizo":                  {"Celeste"},
+		"runner:foo OR host:bar AND platform:snes":                         {"Super Mario 64", "Super Metroid", "Chrono Trigger", "Celeste"},
+		"(runner:foo OR host:bar) AND platform:snes":                       {"Super Metroid", "Chrono Trigger"},
+		"runner:foo OR (host:bar AND platform:snes) AND NOT category:any%": {"Super Mario 64", "Chrono Trigger", "Celeste"},
+	} {
+		f, err := ParseQuery(query)
+		assert.NoError(t, err, "%s", query)
+
+		got := []string{}
+		for _, run := range runs {
+			if f(run) {
+				got = append(got, run.Title)
+			}
+		}
+		assert.Equal(t, want, got, "%s", query)
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	for query, msg := range map[string]string{
+		"":                    "empty query",
+		"runner:":             "missing value",
+		"speed:fast":          `unknown field "speed"`,
+		`title:"super`:        "unterminated quote",
+		"(runner:foo":         "missing )",
+		"runner:foo)":         `unexpected ")"`,
+		"runner:foo OR":       "unexpected end of query",
+		"AND runner:foo":      `unexpected "AND"`,
+		"runner:foo AND OR x": `unexpected "OR" at position 15`,
+	} {
+		_, err := ParseQuery(query)
+		assert.Error(t, err, query)
+		assert.Contains(t, err.Error(), msg, query)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	s := NewScheduleFrom(testRuns)
+	f, err := ParseQuery("runner:fantastic OR host:awesome")
+	assert.NoError(t, err)
+
+	got := s.Filter(f)
+	assert.Equal(t, 2, len(got.Runs))
+	assert.Equal(t, "Game 4", got.Runs[0].Title)
+	assert.Equal(t, "Game 5", got.Runs[1].Title)
+
+	title, err := ParseQuery("title:game")
+	assert.NoError(t, err)
+	assert.Equal(t, s.ForTitle("game").Runs, s.Filter(title).Runs)
+
+	start := testStart
+	late := &Run{Title: "late", Start: start.Add(time.Hour)}
+	early := &Run{Title: "early", Start: start}
+	got = NewScheduleFrom([]*Run{late, early}).Filter(func(*Run) bool { return true })
+	assert.Equal(t, []*Run{early, late}, got.Runs)
+
+	assert.Zero(t, s.Filter(func(*Run) bool { return false }))
+	assert.Zero(t, (*Schedule)(nil).Filter(f))
+}
@@ -0,0 +1,200 @@
+package gdq
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// RecordMode determines what a [Recorder] does with a request.
+type RecordMode int
+
+const (
+	// ModeReplay serves every request from a recording, and fails requests
+	// for which there is none.
+	ModeReplay RecordMode = iota
+	// ModeRecord sends every request to the tracker and records the
+	// response, replacing any existing recording.
+	ModeRecord
+	// ModeReplayOrRecord serves a request from a recording if there is one,
+	// and records it otherwise.
+	ModeReplayOrRecord
+)
+
+// Recorder is an [http.RoundTripper] that records responses from the
+// tracker to files, and replays them later.
+//
+// Use it with [WithHTTPClient] to run code against a snapshot of the
+// tracker, without network access. Every response is stored as indented
+// JSON in its own file in Dir, named after the endpoint it came from. The
+// runs for event 34 end up in runs-34.json, for example. Only 200 OK
+// responses are recorded, anything else is passed through as is.
+//
+// Redact is called with the body of every response before it's recorded,
+// and can be used to remove personal information. [RedactDonors] removes
+// the names of donors. The redacted body is also what's returned when
+// recording, so recording and replaying behave the same.
+type Recorder struct {
+	Dir       string
+	Mode      RecordMode
+	Transport http.RoundTripper
+	Redact    func(u *url.URL, body []byte) ([]byte, error)
+}
+
+// RoundTrip implements [http.RoundTripper].
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := filepath.Join(r.Dir, RecordingName(req.URL))
+
+	if r.Mode != ModeRecord {
+		body, err := os.ReadFile(path)
+		if err == nil {
+			return replayed(req, body), nil
+		}
+		if r.Mode == ModeReplay {
+			return nil, fmt.Errorf("no recording for %s: %w", req.URL, err)
+		}
+	}
+
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if r.Redact != nil {
+		body, err = r.Redact(req.URL, body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to redact %s: %w", req.URL, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, body, "", "  "); err != nil {
+		buf.Reset()
+		buf.Write(body)
+	}
+	buf.WriteString("\n")
+
+	if err := os.MkdirAll(r.Dir, 0o755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return nil, fmt.Errorf("failed to record %s: %w", req.URL, err)
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	resp.Header.Del("Content-Length")
+	return resp, nil
+}
+
+func replayed(req *http.Request, body []byte) *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+var unsafeChars = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// RecordingName returns the name of the file a [Recorder] stores the
+// response for u in.
+//
+// It's made up of the kind of resource, the IDs in the path, the other
+// path segments following the first ID and the query parameters, like
+// runs-34.json, bids-34-tree.json or events-34-totals-true.json.
+func RecordingName(u *url.URL) string {
+	res := resource(u.String())
+	parts := []string{res}
+	var ids, rest []string
+	for _, seg := range strings.FieldsFunc(u.Path, func(r rune) bool { return r == '/' }) {
+		if _, err := strconv.ParseUint(seg, 10, 64); err == nil {
+			ids = append(ids, seg)
+			continue
+		}
+		if len(ids) > 0 && seg != res {
+			rest = append(rest, seg)
+		}
+	}
+	parts = append(parts, ids...)
+	parts = append(parts, rest...)
+
+	q := u.Query()
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		parts = append(parts, k)
+		for _, v := range q[k] {
+			if v != "" {
+				parts = append(parts, v)
+			}
+		}
+	}
+
+	name := unsafeChars.ReplaceAllString(strings.Join(parts, "-"), "-")
+	name = strings.Trim(name, "-")
+	if name == "" {
+		name = "index"
+	}
+	return name + ".json"
+}
+
+// RedactDonors replaces the name of every donor in a response with
+// "(Anonymous)", the way the tracker shows anonymous donations.
+//
+// It can be used as the Redact function of a [Recorder].
+func RedactDonors(_ *url.URL, body []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	redact(v, "donor_name", "(Anonymous)")
+	return json.Marshal(v)
+}
+
+// redact replaces the value of every key in v with value.
+func redact(v any, key string, value any) {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, e := range v {
+			if k == key {
+				v[k] = value
+				continue
+			}
+			redact(e, key, value)
+		}
+	case []any:
+		for _, e := range v {
+			redact(e, key, value)
+		}
+	}
+}
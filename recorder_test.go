@@ -0,0 +1,85 @@
+package gdq
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestRecordingName(t *testing.T) {
+	for in, out := range map[string]string{
+		"https://gamesdonequick.com/tracker/api/v2/events/":                    "events.json",
+		"https://gamesdonequick.com/tracker/api/v2/events/34/?totals=true":     "events-34-totals-true.json",
+		"https://gamesdonequick.com/tracker/api/v2/events/34/runs/":            "runs-34.json",
+		"https://gamesdonequick.com/tracker/api/v2/events/34/runs/?offset=100": "runs-34-offset-100.json",
+		"https://gamesdonequick.com/tracker/api/v2/events/34/bids/":            "bids-34.json",
+		"https://gamesdonequick.com/tracker/api/v2/events/34/bids/tree/":       "bids-34-tree.json",
+		"https://gamesdonequick.com/tracker/api/v2/talent/884/":                "talent-884.json",
+		"http://localhost/events/34/donations/?page=2&limit=10":                "donations-34-limit-10-page-2.json",
+		"http://localhost/": "index.json",
+	} {
+		u, err := url.Parse(in)
+		assert.NoError(t, err)
+		assert.Equal(t, out, RecordingName(u), in)
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/events/34/donations/" {
+			fmt.Fprint(w, `{"count":1,"next":null,"previous":null,"results":[{"type":"donation","id":1,"donor_name":"Jane Doe","amount":25.5,"currency":"USD","comment":"Go fast!"}]}`)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+
+	dir := t.TempDir()
+	rec := &Recorder{Dir: dir, Mode: ModeRecord, Redact: RedactDonors}
+	c := New(WithBaseURL(ts.URL), WithHTTPClient(&http.Client{Transport: rec}))
+
+	var recorded []*DonationEntry
+	for d, err := range c.Donations(context.Background(), 34) {
+		assert.NoError(t, err)
+		recorded = append(recorded, d)
+	}
+	assert.Equal(t, 1, len(recorded))
+	assert.Equal(t, "(Anonymous)", recorded[0].Donor)
+	assert.Equal(t, NewMoney(2550, "USD"), recorded[0].Amount)
+
+	data, err := os.ReadFile(filepath.Join(dir, "donations-34.json"))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "Jane Doe")
+
+	_, err = c.Talent(context.Background(), 1)
+	assert.IsError(t, err, ErrNotFound)
+	_, err = os.Stat(filepath.Join(dir, "talent-1.json"))
+	assert.True(t, os.IsNotExist(err))
+
+	ts.Close()
+
+	rec.Mode = ModeReplay
+	var replayed []*DonationEntry
+	for d, err := range c.Donations(context.Background(), 34) {
+		assert.NoError(t, err)
+		replayed = append(replayed, d)
+	}
+	assert.Equal(t, recorded, replayed)
+
+	_, err = c.Talent(context.Background(), 1)
+	assert.IsError(t, err, os.ErrNotExist)
+}
+
+func TestRecorderTestdata(t *testing.T) {
+	c := New(WithHTTPClient(&http.Client{Transport: &Recorder{Dir: "testdata"}}))
+
+	runs, err := c.Runs(context.Background(), 34)
+	assert.NoError(t, err)
+	assert.Equal(t, 157, len(runs))
+}
@@ -0,0 +1,117 @@
+package gdq
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how a [Client] retries requests that failed due to
+// a transient problem.
+//
+// A request is retried when the tracker responds with 429 Too Many Requests
+// or a 5xx status code, when the request failed before a response was
+// received, or when the attempt took longer than the timeout set with
+// [WithTimeout]. It's never retried once the context of the caller has been
+// cancelled or its deadline exceeded. Only GET and HEAD requests are
+// retried, since they're safe to repeat.
+//
+// MaxAttempts is the number of times a request is attempted, including the
+// first attempt. A value of 1 or less disables retries.
+//
+// Between attempts the client waits a random duration between 0 and
+// BaseDelay, doubling the upper bound for every attempt but never going
+// over MaxDelay. When the tracker includes a Retry-After header, the
+// client waits for that long instead, capped at MaxDelay.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// DefaultRetryPolicy is a reasonable policy for long running programs.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    30 * time.Second,
+}
+
+// delay returns how long to wait before the next attempt, after attempt
+// number of attempts have failed with err.
+func (p RetryPolicy) delay(attempt int, err error) time.Duration {
+	var aerr *APIError
+	if errors.As(err, &aerr) && aerr.RetryAfter > 0 {
+		return min(aerr.RetryAfter, p.maxDelay())
+	}
+
+	ceil := p.BaseDelay << (attempt - 1)
+	if ceil <= 0 || ceil > p.maxDelay() {
+		// A negative ceiling means the shift overflowed
+		ceil = p.maxDelay()
+	}
+	if ceil <= 0 {
+		return 0
+	}
+	return rand.N(ceil + 1)
+}
+
+func (p RetryPolicy) maxDelay() time.Duration {
+	if p.MaxDelay <= 0 {
+		return DefaultRetryPolicy.MaxDelay
+	}
+	return p.MaxDelay
+}
+
+// retryable returns whether the request with method that failed with err is
+// worth another attempt. ctx is the context of the caller, not the one of
+// the attempt, so attempts that timed out can be told apart from callers
+// that gave up.
+func retryable(ctx context.Context, method string, err error) bool {
+	if method != http.MethodGet && method != http.MethodHead {
+		return false
+	}
+	if ctx.Err() != nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var aerr *APIError
+	if errors.As(err, &aerr) {
+		return errors.Is(aerr, ErrRateLimited) || errors.Is(aerr, ErrServer)
+	}
+	return true
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either
+// a number of seconds or an HTTP date.
+func parseRetryAfter(v string, now time.Time) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// sleep waits for d or until ctx is done, whichever comes first.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
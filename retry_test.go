@@ -0,0 +1,163 @@
+package gdq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2021, 1, 3, 12, 0, 0, 0, time.UTC)
+	t.Run("empty", func(t *testing.T) {
+		assert.Equal(t, 0, parseRetryAfter("", now))
+	})
+	t.Run("seconds", func(t *testing.T) {
+		assert.Equal(t, 120*time.Second, parseRetryAfter("120", now))
+	})
+	t.Run("negative seconds", func(t *testing.T) {
+		assert.Equal(t, 0, parseRetryAfter("-1", now))
+	})
+	t.Run("date", func(t *testing.T) {
+		assert.Equal(t, 30*time.Second, parseRetryAfter(now.Add(30*time.Second).Format(http.TimeFormat), now))
+	})
+	t.Run("date in the past", func(t *testing.T) {
+		assert.Equal(t, 0, parseRetryAfter(now.Add(-30*time.Second).Format(http.TimeFormat), now))
+	})
+	t.Run("garbage", func(t *testing.T) {
+		assert.Equal(t, 0, parseRetryAfter("banana", now))
+	})
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 10, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	t.Run("backoff", func(t *testing.T) {
+		for attempt := 1; attempt < 64; attempt++ {
+			ceil := min(p.BaseDelay<<(attempt-1), p.MaxDelay)
+			if ceil <= 0 {
+				ceil = p.MaxDelay
+			}
+			d := p.delay(attempt, errors.New("boom"))
+			assert.True(t, d >= 0 && d <= ceil, "attempt %d: %s not within %s", attempt, d, ceil)
+		}
+	})
+	t.Run("retry after", func(t *testing.T) {
+		assert.Equal(t, 500*time.Millisecond, p.delay(1, &APIError{StatusCode: 429, RetryAfter: 500 * time.Millisecond}))
+	})
+	t.Run("retry after is capped", func(t *testing.T) {
+		assert.Equal(t, time.Second, p.delay(1, &APIError{StatusCode: 429, RetryAfter: time.Hour}))
+	})
+}
+
+func TestRetryable(t *testing.T) {
+	ctx := context.Background()
+	assert.True(t, retryable(ctx, http.MethodGet, errors.New("connection reset")))
+	assert.True(t, retryable(ctx, http.MethodGet, &APIError{StatusCode: http.StatusTooManyRequests}))
+	assert.True(t, retryable(ctx, http.MethodGet, &APIError{StatusCode: http.StatusBadGateway}))
+	assert.True(t, retryable(ctx, http.MethodHead, &APIError{StatusCode: http.StatusBadGateway}))
+	assert.False(t, retryable(ctx, http.MethodGet, &APIError{StatusCode: http.StatusNotFound}))
+	assert.False(t, retryable(ctx, http.MethodGet, fmt.Errorf("wrapped: %w", context.Canceled)))
+	assert.False(t, retryable(ctx, http.MethodPost, &APIError{StatusCode: http.StatusBadGateway}))
+
+	t.Run("timeouts", func(t *testing.T) {
+		assert.True(t, retryable(ctx, http.MethodGet, fmt.Errorf("wrapped: %w", context.DeadlineExceeded)))
+
+		done, cancel := context.WithTimeout(ctx, 0)
+		defer cancel()
+		<-done.Done()
+		assert.False(t, retryable(done, http.MethodGet, fmt.Errorf("wrapped: %w", context.DeadlineExceeded)))
+	})
+}
+
+func TestClientRetries(t *testing.T) {
+	newServer := func(failures int32, status int) (*httptest.Server, *atomic.Int32) {
+		var calls atomic.Int32
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) <= failures {
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(status)
+				return
+			}
+			fmt.Fprint(w, `hello`)
+		}))
+		return ts, &calls
+	}
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		ts, calls := newServer(1, http.StatusServiceUnavailable)
+		defer ts.Close()
+		c := New()
+		_, err := c.get(context.Background(), ts.URL)
+		assert.IsError(t, err, ErrServer)
+		assert.Equal(t, 1, calls.Load())
+	})
+	t.Run("recovers", func(t *testing.T) {
+		ts, calls := newServer(2, http.StatusServiceUnavailable)
+		defer ts.Close()
+		c := New(WithRetryPolicy(policy))
+		data, err := c.get(context.Background(), ts.URL)
+		assert.NoError(t, err)
+		assert.Equal(t, "hello", string(data))
+		assert.Equal(t, 3, calls.Load())
+	})
+	t.Run("rate limited", func(t *testing.T) {
+		ts, calls := newServer(1, http.StatusTooManyRequests)
+		defer ts.Close()
+		c := New(WithRetryPolicy(policy))
+		_, err := c.get(context.Background(), ts.URL)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, calls.Load())
+	})
+	t.Run("gives up", func(t *testing.T) {
+		ts, calls := newServer(5, http.StatusServiceUnavailable)
+		defer ts.Close()
+		c := New(WithRetryPolicy(policy))
+		_, err := c.get(context.Background(), ts.URL)
+		assert.IsError(t, err, ErrServer)
+		assert.Equal(t, 3, calls.Load())
+	})
+	t.Run("does not retry client errors", func(t *testing.T) {
+		ts, calls := newServer(5, http.StatusNotFound)
+		defer ts.Close()
+		c := New(WithRetryPolicy(policy))
+		_, err := c.get(context.Background(), ts.URL)
+		assert.IsError(t, err, ErrNotFound)
+		assert.Equal(t, 1, calls.Load())
+	})
+	t.Run("retries slow responses", func(t *testing.T) {
+		var calls atomic.Int32
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) == 1 {
+				select {
+				case <-r.Context().Done():
+				case <-time.After(time.Second):
+				}
+				return
+			}
+			fmt.Fprint(w, `hello`)
+		}))
+		defer ts.Close()
+		c := New(WithRetryPolicy(policy), WithTimeout(20*time.Millisecond))
+		data, err := c.get(context.Background(), ts.URL)
+		assert.NoError(t, err)
+		assert.Equal(t, "hello", string(data))
+		assert.Equal(t, 2, calls.Load())
+	})
+	t.Run("stops when cancelled", func(t *testing.T) {
+		ts, calls := newServer(5, http.StatusServiceUnavailable)
+		defer ts.Close()
+		c := New(WithRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Hour, MaxDelay: time.Hour}))
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := c.get(ctx, ts.URL)
+		assert.IsError(t, err, ErrServer)
+		assert.Equal(t, 1, calls.Load())
+	})
+}
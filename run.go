@@ -4,30 +4,41 @@ import (
 	"time"
 )
 
-type runResp struct {
-	Results []struct {
-		ID           uint      `json:"id"`
-		Name         string    `json:"name"`
-		Category     string    `json:"category"`
-		Console      string    `json:"console"`
-		Runners      []Talent  `json:"runners"`
-		Hosts        []Talent  `json:"hosts"`
-		Commentators []Talent  `json:"commentators"`
-		Starttime    time.Time `json:"starttime"`
-		Endtime      time.Time `json:"endtime"`
-		RunTime      Duration  `json:"run_time"`
-		SetupTime    Duration  `json:"setup_time"`
-	} `json:"results"`
+type runResult struct {
+	ID           uint        `json:"id"`
+	Name         string      `json:"name"`
+	DisplayName  string      `json:"display_name"`
+	TwitchName   string      `json:"twitch_name"`
+	Description  string      `json:"description"`
+	Category     string      `json:"category"`
+	Coop         bool        `json:"coop"`
+	Onsite       Location    `json:"onsite"`
+	Console      string      `json:"console"`
+	ReleaseYear  int         `json:"release_year"`
+	Runners      []Talent    `json:"runners"`
+	Hosts        []Talent    `json:"hosts"`
+	Commentators []Talent    `json:"commentators"`
+	Starttime    time.Time   `json:"starttime"`
+	Endtime      time.Time   `json:"endtime"`
+	Order        int         `json:"order"`
+	RunTime      Duration    `json:"run_time"`
+	SetupTime    Duration    `json:"setup_time"`
+	AnchorTime   time.Time   `json:"anchor_time"`
+	VideoLinks   []VideoLink `json:"video_links"`
+	PriorityTag  string      `json:"priority_tag"`
+	Tags         []string    `json:"tags"`
 }
 
+type runResp []runResult
+
 func (r runResp) toRuns() []*Run {
-	lrun := len(r.Results)
+	lrun := len(r)
 	if lrun == 0 {
 		return nil
 	}
 
 	runs := make([]*Run, 0, lrun)
-	for _, r := range r.Results {
+	for _, r := range r {
 		if r.RunTime.Milliseconds() == 0 {
 			// A lot of older events have their Estimate always set to 0, and the
 			// same for their setup time. When we run into that, subtract the
@@ -36,27 +47,83 @@ func (r runResp) toRuns() []*Run {
 			r.RunTime = Duration{r.Endtime.Sub(r.Starttime)}
 		}
 		runs = append(runs, &Run{
+			ID:           r.ID,
 			Title:        r.Name,
+			DisplayName:  r.DisplayName,
+			TwitchName:   r.TwitchName,
+			Description:  r.Description,
 			Start:        r.Starttime,
 			Estimate:     r.RunTime.Add(r.SetupTime),
+			RunTime:      r.RunTime,
+			SetupTime:    r.SetupTime,
+			AnchorTime:   r.AnchorTime,
+			Order:        r.Order,
 			Category:     r.Category,
 			Platform:     r.Console,
+			ReleaseYear:  r.ReleaseYear,
+			Coop:         r.Coop,
+			Onsite:       r.Onsite,
 			Hosts:        r.Hosts,
 			Runners:      r.Runners,
 			Commentators: r.Commentators,
+			VideoLinks:   r.VideoLinks,
+			PriorityTag:  r.PriorityTag,
+			Tags:         r.Tags,
 		})
 	}
 	return runs
 }
 
+// Location is where the runners of a [Run] are.
+type Location string
+
+const (
+	LocationOnsite Location = "ONSITE"
+	LocationOnline Location = "ONLINE"
+	LocationHybrid Location = "HYBRID"
+)
+
+// VideoLink is a link to a recording of a [Run].
+type VideoLink struct {
+	ID   uint   `json:"id"`
+	Type string `json:"link_type"`
+	URL  string `json:"url"`
+}
+
 // Run represents a single event at a GDQ
+//
+// The Estimate is the sum of the RunTime and the SetupTime. The setup time
+// is the time allotted after the run to set up for the next one, which is
+// when interviews and ads air.
+//
+// The AnchorTime is set when the tracker has pinned the run to start at a
+// specific time. ReleaseYear is 0 when it's unknown.
 type Run struct {
-	Title        string    `json:"title"`
-	Start        time.Time `json:"start"`
-	Estimate     Duration  `json:"estimate"`
-	Runners      []Talent  `json:"runners"`
-	Hosts        []Talent  `json:"hosts"`
-	Commentators []Talent  `json:"commentators"`
-	Category     string    `json:"category"`
-	Platform     string    `json:"platform"`
+	ID           uint        `json:"id"`
+	Title        string      `json:"title"`
+	DisplayName  string      `json:"display_name"`
+	TwitchName   string      `json:"twitch_name"`
+	Description  string      `json:"description"`
+	Start        time.Time   `json:"start"`
+	Estimate     Duration    `json:"estimate"`
+	RunTime      Duration    `json:"run_time"`
+	SetupTime    Duration    `json:"setup_time"`
+	AnchorTime   time.Time   `json:"anchor_time"`
+	Order        int         `json:"order"`
+	Runners      []Talent    `json:"runners"`
+	Hosts        []Talent    `json:"hosts"`
+	Commentators []Talent    `json:"commentators"`
+	Category     string      `json:"category"`
+	Platform     string      `json:"platform"`
+	ReleaseYear  int         `json:"release_year"`
+	Coop         bool        `json:"coop"`
+	Onsite       Location    `json:"onsite"`
+	VideoLinks   []VideoLink `json:"video_links"`
+	PriorityTag  string      `json:"priority_tag"`
+	Tags         []string    `json:"tags"`
+}
+
+// End returns when the run is estimated to end, including setup time.
+func (r *Run) End() time.Time {
+	return r.Start.Add(r.Estimate.Duration)
 }
@@ -2,6 +2,7 @@ package gdq
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -15,18 +16,20 @@ import (
 
 // Schedule represents the runs occurring at a GDQ event.
 type Schedule struct {
-	Runs     []*Run
-	byRunner map[string][]*Run
-	byHost   map[string][]*Run
-	l        sync.RWMutex
+	Runs          []*Run
+	byRunner      map[string][]*Run
+	byHost        map[string][]*Run
+	byCommentator map[string][]*Run
+	l             sync.RWMutex
 }
 
 // NewSchedule returns an empty Schedule.
 func NewSchedule() *Schedule {
 	return &Schedule{
-		Runs:     []*Run{},
-		byRunner: map[string][]*Run{},
-		byHost:   map[string][]*Run{},
+		Runs:          []*Run{},
+		byRunner:      map[string][]*Run{},
+		byHost:        map[string][]*Run{},
+		byCommentator: map[string][]*Run{},
 	}
 }
 
@@ -37,16 +40,17 @@ func NewScheduleFrom(runs []*Run) *Schedule {
 	}
 
 	s := &Schedule{
-		Runs:     runs,
-		byRunner: map[string][]*Run{},
-		byHost:   map[string][]*Run{},
+		Runs:          runs,
+		byRunner:      map[string][]*Run{},
+		byHost:        map[string][]*Run{},
+		byCommentator: map[string][]*Run{},
 	}
 
 	s.calc()
 	return s
 }
 
-// calc computes the byHost and byRunner lookup maps.
+// calc computes the byHost, byRunner and byCommentator lookup maps.
 func (s *Schedule) calc() {
 	s.l.Lock()
 	defer s.l.Unlock()
@@ -68,6 +72,14 @@ func (s *Schedule) calc() {
 				s.byHost[name] = []*Run{run}
 			}
 		}
+		for _, talent := range run.Commentators {
+			name := normalised(talent.Name)
+			if cev, ok := s.byCommentator[name]; ok {
+				s.byCommentator[name] = append(cev, run)
+			} else {
+				s.byCommentator[name] = []*Run{run}
+			}
+		}
 	}
 }
 
@@ -97,6 +109,20 @@ func (s *Schedule) ForHost(name string) *Schedule {
 	return s.forEntity("host", name)
 }
 
+// ForCommentator returns a new schedule with runs only matching this
+// commentator.
+//
+// The commentator's name is matched using a substring match. This means that
+// if you call something like schedule.ForCommentator("b") you can get a
+// schedule with runs for multiple commentators.
+//
+// You'll get a nil schedule if no run matched the commentator.
+//
+// The match is case insensitive.
+func (s *Schedule) ForCommentator(name string) *Schedule {
+	return s.forEntity("commentator", name)
+}
+
 // ForTitle returns a new schedule with runs only matching the title.
 //
 // The title is matched using a substring match. This means that if you call
@@ -110,32 +136,183 @@ func (s *Schedule) ForTitle(title string) *Schedule {
 	return s.forEntity("title", title)
 }
 
+// ForCategory returns a new schedule with runs only matching the category.
+//
+// The category is matched using a substring match, so
+// schedule.ForCategory("any%") also matches runs of Any% NMG.
+//
+// You'll get a nil schedule if no run matched the category.
+//
+// The match is case insensitive.
+func (s *Schedule) ForCategory(category string) *Schedule {
+	return s.forEntity("category", category)
+}
+
+// ForPlatform returns a new schedule with runs only matching the platform.
+//
+// The platform is matched using a substring match, so
+// schedule.ForPlatform("nes") also matches runs on the SNES.
+//
+// You'll get a nil schedule if no run matched the platform.
+//
+// The match is case insensitive.
+func (s *Schedule) ForPlatform(platform string) *Schedule {
+	return s.forEntity("platform", platform)
+}
+
+// Role is the part someone plays in a run.
+//
+// It marshals to text as its name, so a [TalentMatch] marshals to JSON with
+// roles like ["runner","host"].
+type Role int
+
+const (
+	// RoleRunner is someone who plays the game, one of the Runners of a run.
+	RoleRunner Role = iota + 1
+	// RoleHost is someone who hosts the run, one of the Hosts of a run.
+	RoleHost
+	// RoleCommentator is someone who talks about the run while it's going
+	// on, one of the Commentators of a run.
+	RoleCommentator
+)
+
+// String returns the name of the role, like runner.
+func (r Role) String() string {
+	switch r {
+	case RoleRunner:
+		return "runner"
+	case RoleHost:
+		return "host"
+	case RoleCommentator:
+		return "commentator"
+	default:
+		return fmt.Sprintf("Role(%d)", int(r))
+	}
+}
+
+// MarshalText marshals the role to its name.
+func (r Role) MarshalText() ([]byte, error) {
+	switch r {
+	case RoleRunner, RoleHost, RoleCommentator:
+		return []byte(r.String()), nil
+	default:
+		return nil, fmt.Errorf("invalid role %d", int(r))
+	}
+}
+
+// UnmarshalText unmarshals a role from its name.
+func (r *Role) UnmarshalText(b []byte) error {
+	for _, role := range []Role{RoleRunner, RoleHost, RoleCommentator} {
+		if string(b) == role.String() {
+			*r = role
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid role %q", b)
+}
+
+// TalentMatch is a run someone is involved in, together with the roles they
+// have in it.
+type TalentMatch struct {
+	Run   *Run   `json:"run"`
+	Roles []Role `json:"roles"`
+}
+
+// ForTalent returns the runs someone is involved in, whether as a runner, a
+// host or a commentator. Every run is included once, sorted by start time,
+// together with the roles the person has in it.
+//
+// The name is matched the same way as by [Schedule.ForRunner], so a match
+// can include runs for multiple people. It returns nil if nobody matched.
+func (s *Schedule) ForTalent(name string) []TalentMatch {
+	if s == nil || strings.TrimSpace(name) == "" {
+		return nil
+	}
+
+	match := normalised(name)
+	roles := map[*Run][]Role{}
+
+	s.l.RLock()
+	for _, idx := range []struct {
+		role Role
+		runs map[string][]*Run
+	}{
+		{RoleRunner, s.byRunner},
+		{RoleHost, s.byHost},
+		{RoleCommentator, s.byCommentator},
+	} {
+		for n, rs := range idx.runs {
+			if !strings.Contains(n, match) {
+				continue
+			}
+			for _, run := range rs {
+				if !slices.Contains(roles[run], idx.role) {
+					roles[run] = append(roles[run], idx.role)
+				}
+			}
+		}
+	}
+	s.l.RUnlock()
+
+	var matches []TalentMatch
+	for _, run := range s.sortedFunc(func(run *Run) bool { return roles[run] != nil }) {
+		matches = append(matches, TalentMatch{Run: run, Roles: roles[run]})
+	}
+	return matches
+}
+
+// forEntity returns a new schedule with the runs where the kind of entity
+// matches. Every run is included once, sorted by start time.
 func (s *Schedule) forEntity(kind string, match string) *Schedule {
-	if strings.TrimSpace(match) == "" {
+	if s == nil || strings.TrimSpace(match) == "" {
 		return nil
 	}
 
 	match = normalised(match)
-	matched := make([]*Run, 0, 8)
+	matched := map[*Run]bool{}
 
 	s.l.RLock()
 	switch kind {
 	case "title":
 		for _, run := range s.Runs {
 			if strings.Contains(normalised(run.Title), match) {
-				matched = append(matched, run)
+				matched[run] = true
+			}
+		}
+	case "category":
+		for _, run := range s.Runs {
+			if strings.Contains(normalised(run.Category), match) {
+				matched[run] = true
+			}
+		}
+	case "platform":
+		for _, run := range s.Runs {
+			if strings.Contains(normalised(run.Platform), match) {
+				matched[run] = true
 			}
 		}
 	case "host":
 		for h, rs := range s.byHost {
 			if strings.Contains(h, match) {
-				matched = append(matched, rs...)
+				for _, run := range rs {
+					matched[run] = true
+				}
 			}
 		}
 	case "runner":
 		for h, rs := range s.byRunner {
 			if strings.Contains(h, match) {
-				matched = append(matched, rs...)
+				for _, run := range rs {
+					matched[run] = true
+				}
+			}
+		}
+	case "commentator":
+		for h, rs := range s.byCommentator {
+			if strings.Contains(h, match) {
+				for _, run := range rs {
+					matched[run] = true
+				}
 			}
 		}
 	default:
@@ -143,27 +320,183 @@ func (s *Schedule) forEntity(kind string, match string) *Schedule {
 	}
 	s.l.RUnlock()
 
-	if len(matched) == 0 {
+	return NewScheduleFrom(s.sortedFunc(func(run *Run) bool {
+		return matched[run]
+	}))
+}
+
+// Union returns a new schedule with the runs that are in either s or o.
+//
+// Runs are considered the same if they have the same ID, or if they're the
+// same *Run when they don't have one. Every run is included once, sorted by
+// start time. You'll get a nil schedule if there are no runs left.
+func (s *Schedule) Union(o *Schedule) *Schedule {
+	return NewScheduleFrom(sortedRuns(append(s.runs(), o.runs()...)))
+}
+
+// Intersect returns a new schedule with the runs that are in both s and o.
+// Runs are compared and ordered the same way as in [Schedule.Union].
+func (s *Schedule) Intersect(o *Schedule) *Schedule {
+	in := runSet(o.runs())
+	return NewScheduleFrom(sortedRuns(slices.DeleteFunc(s.runs(), func(run *Run) bool {
+		return !in[runKey(run)]
+	})))
+}
+
+// Difference returns a new schedule with the runs that are in s but not in
+// o. Runs are compared and ordered the same way as in [Schedule.Union].
+func (s *Schedule) Difference(o *Schedule) *Schedule {
+	in := runSet(o.runs())
+	return NewScheduleFrom(sortedRuns(slices.DeleteFunc(s.runs(), func(run *Run) bool {
+		return in[runKey(run)]
+	})))
+}
+
+// runs returns a copy of the runs in s, which may be nil.
+func (s *Schedule) runs() []*Run {
+	if s == nil {
 		return nil
 	}
 
-	return NewScheduleFrom(matched)
+	s.l.RLock()
+	defer s.l.RUnlock()
+	return slices.Clone(s.Runs)
+}
+
+// runKey identifies a run, by its ID if it has one.
+func runKey(run *Run) any {
+	if run.ID != 0 {
+		return run.ID
+	}
+	return run
+}
+
+func runSet(runs []*Run) map[any]bool {
+	set := make(map[any]bool, len(runs))
+	for _, run := range runs {
+		set[runKey(run)] = true
+	}
+	return set
 }
 
-// NextRun returns the next run in the [Schedule].
+// sortedRuns removes duplicate runs, keeping the first, and sorts them by
+// start time. Runs that start at the same time keep their order.
+func sortedRuns(runs []*Run) []*Run {
+	seen := map[any]bool{}
+	runs = slices.DeleteFunc(runs, func(run *Run) bool {
+		k := runKey(run)
+		if seen[k] {
+			return true
+		}
+		seen[k] = true
+		return false
+	})
+	slices.SortStableFunc(runs, func(a, b *Run) int {
+		return a.Start.Compare(b.Start)
+	})
+	return runs
+}
+
+// Filter returns a new schedule with only the runs for which f returns
+// true, sorted by start time. Use it with [ParseQuery] for filters that
+// can't be expressed by chaining the other filters.
 //
-// It returns the first run after t.
-func (s *Schedule) NextRun(t time.Time) *Run {
+// You'll get a nil schedule if no run matched.
+func (s *Schedule) Filter(f func(*Run) bool) *Schedule {
+	return NewScheduleFrom(s.sortedFunc(f))
+}
+
+// RunByID returns the run with the tracker's run ID.
+//
+// It returns nil if the run isn't in the [Schedule].
+func (s *Schedule) RunByID(id uint) *Run {
+	if s == nil {
+		return nil
+	}
+
 	s.l.RLock()
 	defer s.l.RUnlock()
 	for _, run := range s.Runs {
-		if run.Start.After(t) {
+		if run.ID == id {
 			return run
 		}
 	}
 	return nil
 }
 
+// NextRun returns the next run in the [Schedule].
+//
+// It returns the run that starts first after t, or nil if there isn't one.
+func (s *Schedule) NextRun(t time.Time) *Run {
+	if runs := s.Upcoming(t, 1); len(runs) > 0 {
+		return runs[0]
+	}
+	return nil
+}
+
+// End returns the end of the run that ends last, or the zero time if the
+// schedule has no runs.
+func (s *Schedule) End() time.Time {
+	var end time.Time
+	for _, run := range s.runs() {
+		if e := run.End(); e.After(end) {
+			end = e
+		}
+	}
+	return end
+}
+
+// CurrentRun returns the run that's in progress at t, including its setup
+// time. If more than one run is in progress, it's the one that started last.
+//
+// It returns nil if no run is in progress, which can happen in a filtered
+// schedule or between the runs of a schedule with gaps.
+func (s *Schedule) CurrentRun(t time.Time) *Run {
+	if runs := s.At(t); len(runs) > 0 {
+		return runs[len(runs)-1]
+	}
+	return nil
+}
+
+// At returns the runs that were in progress at t, sorted by start time.
+//
+// Runs normally don't overlap, so this is usually at most one run. Use it
+// to find out what was live at some point in the past.
+func (s *Schedule) At(t time.Time) []*Run {
+	return s.sortedFunc(func(run *Run) bool {
+		return !run.Start.After(t) && run.End().After(t)
+	})
+}
+
+// Between returns the runs that are in progress at some point between from
+// and to, sorted by start time. Runs that started before from but are still
+// going on are included, as are runs that start before to.
+func (s *Schedule) Between(from, to time.Time) []*Run {
+	return s.sortedFunc(func(run *Run) bool {
+		return run.Start.Before(to) && run.End().After(from)
+	})
+}
+
+// Upcoming returns the first n runs that start after t, sorted by start
+// time.
+func (s *Schedule) Upcoming(t time.Time, n int) []*Run {
+	if n <= 0 {
+		return nil
+	}
+	runs := s.sortedFunc(func(run *Run) bool {
+		return run.Start.After(t)
+	})
+	return runs[:min(n, len(runs))]
+}
+
+// sortedFunc returns the runs matching f, sorted by start time. Runs that
+// start at the same time keep the order they have in the schedule.
+func (s *Schedule) sortedFunc(f func(*Run) bool) []*Run {
+	return sortedRuns(slices.DeleteFunc(s.runs(), func(run *Run) bool {
+		return !f(run)
+	}))
+}
+
 // normalised transforms a string to a variant that has punctuation and
 // diacritics removed, and is mapped to lower case
 func normalised(s string) string {
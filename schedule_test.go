@@ -1,6 +1,7 @@
 package gdq
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -36,6 +37,20 @@ var testRuns = []*Run{
 	},
 }
 
+// testStart is the start of the first run in tests that need fixed times.
+var testStart = time.Date(2021, time.January, 3, 16, 30, 0, 0, time.UTC)
+
+// runIDs returns the IDs of the runs in a schedule, in order.
+func runIDs(s *Schedule) []uint {
+	res := []uint{}
+	if s != nil {
+		for _, run := range s.Runs {
+			res = append(res, run.ID)
+		}
+	}
+	return res
+}
+
 func TestNewScheduleFrom(t *testing.T) {
 	t.Run("no runs", func(t *testing.T) {
 		runs := []*Run{}
@@ -153,3 +168,160 @@ func TestForTitle(t *testing.T) {
 		assert.Equal(t, 5, len(s.ForTitle(" ga ").Runs))
 	})
 }
+
+func TestTimeQueries(t *testing.T) {
+	start := testStart
+	at := func(d time.Duration) time.Time { return start.Add(d) }
+	run := func(title string, from, length time.Duration) *Run {
+		return &Run{Title: title, Start: at(from), Estimate: Duration{length}}
+	}
+	titles := func(runs []*Run) []string {
+		res := []string{}
+		for _, r := range runs {
+			res = append(res, r.Title)
+		}
+		return res
+	}
+
+	// Deliberately out of order, with a gap between Game 3 and Game 4.
+	s := NewScheduleFrom([]*Run{
+		run("Game 3", time.Hour, 30*time.Minute),
+		run("Game 1", 0, 20*time.Minute),
+		run("Game 4", 2*time.Hour, time.Hour),
+		run("Game 2", 20*time.Minute, 40*time.Minute),
+	})
+
+	t.Run("End", func(t *testing.T) {
+		assert.Equal(t, at(3*time.Hour), s.End())
+		assert.True(t, NewScheduleFrom(nil).End().IsZero())
+	})
+	t.Run("NextRun", func(t *testing.T) {
+		assert.Equal(t, "Game 1", s.NextRun(at(-time.Minute)).Title)
+		assert.Equal(t, "Game 4", s.NextRun(at(time.Hour)).Title)
+		assert.Zero(t, s.NextRun(at(2*time.Hour)))
+	})
+	t.Run("CurrentRun", func(t *testing.T) {
+		assert.Zero(t, s.CurrentRun(at(-time.Minute)))
+		assert.Equal(t, "Game 1", s.CurrentRun(at(0)).Title)
+		assert.Equal(t, "Game 2", s.CurrentRun(at(20*time.Minute)).Title)
+		assert.Zero(t, s.CurrentRun(at(100*time.Minute)))
+		assert.Zero(t, s.CurrentRun(at(3*time.Hour)))
+	})
+	t.Run("At", func(t *testing.T) {
+		assert.Equal(t, []string{"Game 3"}, titles(s.At(at(75*time.Minute))))
+		overlap := NewScheduleFrom([]*Run{run("B", 10*time.Minute, time.Hour), run("A", 0, time.Hour)})
+		assert.Equal(t, []string{"A", "B"}, titles(overlap.At(at(30*time.Minute))))
+		assert.Equal(t, "B", overlap.CurrentRun(at(30*time.Minute)).Title)
+	})
+	t.Run("Between", func(t *testing.T) {
+		assert.Equal(t, []string{"Game 2", "Game 3"}, titles(s.Between(at(30*time.Minute), at(90*time.Minute))))
+		assert.Equal(t, []string{}, titles(s.Between(at(100*time.Minute), at(2*time.Hour))))
+		assert.Equal(t, []string{"Game 1", "Game 2", "Game 3", "Game 4"}, titles(s.Between(at(-time.Hour), at(5*time.Hour))))
+	})
+	t.Run("Upcoming", func(t *testing.T) {
+		assert.Equal(t, []string{"Game 2", "Game 3"}, titles(s.Upcoming(at(0), 2)))
+		assert.Equal(t, []string{"Game 4"}, titles(s.Upcoming(at(time.Hour), 5)))
+		assert.Equal(t, []string{}, titles(s.Upcoming(at(0), 0)))
+	})
+	t.Run("nil schedule", func(t *testing.T) {
+		var s *Schedule
+		assert.Zero(t, s.NextRun(start))
+		assert.Zero(t, s.CurrentRun(start))
+		assert.Zero(t, s.Upcoming(start, 3))
+	})
+}
+
+func TestForEntityDeduplicates(t *testing.T) {
+	start := testStart
+	s := NewScheduleFrom([]*Run{
+		{ID: 2, Title: "Game 2", Start: start.Add(time.Hour), Runners: []Talent{{Name: "amazing"}}},
+		{ID: 1, Title: "Game 1", Start: start, Runners: []Talent{{Name: "amazing"}, {Name: "amazed"}}},
+		{ID: 3, Title: "Game 3", Start: start.Add(2 * time.Hour), Runners: []Talent{{Name: "amazed"}}},
+	})
+
+	for range 10 {
+		got := s.ForRunner("amaz")
+		assert.Equal(t, 3, len(got.Runs))
+		assert.Equal(t, []uint{1, 2, 3}, []uint{got.Runs[0].ID, got.Runs[1].ID, got.Runs[2].ID})
+	}
+	assert.Zero(t, s.ForRunner("nobody").ForHost("anyone"))
+}
+
+func TestSetOperations(t *testing.T) {
+	start := testStart
+	runs := []*Run{
+		{ID: 1, Title: "Game 1", Start: start, Runners: []Talent{{Name: "amazing"}}},
+		{ID: 2, Title: "Game 2", Start: start.Add(time.Hour), Hosts: []Talent{{Name: "wonderful"}}},
+		{ID: 3, Title: "Game 3", Start: start.Add(2 * time.Hour), Runners: []Talent{{Name: "amazing"}}, Hosts: []Talent{{Name: "wonderful"}}},
+		{ID: 4, Title: "Game 4", Start: start.Add(3 * time.Hour)},
+	}
+	s := NewScheduleFrom(runs)
+	runner := s.ForRunner("amazing")
+	host := s.ForHost("wonderful")
+
+	assert.Equal(t, []uint{1, 2, 3}, runIDs(runner.Union(host)))
+	assert.Equal(t, []uint{1, 2, 3}, runIDs(host.Union(runner)))
+	assert.Equal(t, []uint{3}, runIDs(runner.Intersect(host)))
+	assert.Equal(t, []uint{1}, runIDs(runner.Difference(host)))
+	assert.Equal(t, []uint{2}, runIDs(host.Difference(runner)))
+	assert.Equal(t, []uint{4}, runIDs(s.Difference(runner.Union(host))))
+	assert.Zero(t, runner.Difference(runner))
+
+	t.Run("nil schedules", func(t *testing.T) {
+		var none *Schedule
+		assert.Equal(t, []uint{1, 3}, runIDs(none.Union(runner)))
+		assert.Zero(t, none.Intersect(runner))
+		assert.Zero(t, runner.Intersect(none))
+		assert.Equal(t, []uint{1, 3}, runIDs(runner.Difference(none)))
+	})
+	t.Run("matched by ID", func(t *testing.T) {
+		refetched := NewScheduleFrom([]*Run{{ID: 3, Title: "Game 3", Start: start.Add(2 * time.Hour)}})
+		assert.Equal(t, []uint{1, 3}, runIDs(runner.Union(refetched)))
+		assert.Equal(t, []uint{3}, runIDs(runner.Intersect(refetched)))
+	})
+}
+
+func TestForRoleAndAttributes(t *testing.T) {
+	start := testStart
+	s := NewScheduleFrom([]*Run{
+		{ID: 3, Title: "Celeste", Start: start.Add(2 * time.Hour), Category: "Any%", Platform: "PC", Runners: []Talent{{Name: "Kungfufruitcup"}}, Commentators: []Talent{{Name: "Pokémon"}}},
+		{ID: 1, Title: "Super Metroid", Start: start, Category: "Any% NMG", Platform: "SNES", Runners: []Talent{{Name: "zoast"}}, Hosts: []Talent{{Name: "kungfu"}}},
+		{ID: 2, Title: "Zelda II", Start: start.Add(time.Hour), Category: "Glitchless", Platform: "NES", Hosts: []Talent{{Name: "Kungfufruitcup"}}, Commentators: []Talent{{Name: "kungfufruitcup"}}},
+	})
+
+	assert.Equal(t, 2, len(s.byCommentator))
+	assert.Equal(t, []uint{2}, runIDs(s.ForCommentator("kungfu")))
+	assert.Equal(t, []uint{3}, runIDs(s.ForCommentator("pokemon")))
+	assert.Equal(t, []uint{1, 3}, runIDs(s.ForCategory("any%")))
+	assert.Equal(t, []uint{1, 2}, runIDs(s.ForPlatform("nes")))
+	assert.Zero(t, s.ForPlatform("n64"))
+
+	t.Run("ForTalent", func(t *testing.T) {
+		got := s.ForTalent("KungFu")
+		assert.Equal(t, 3, len(got))
+		assert.Equal(t, uint(1), got[0].Run.ID)
+		assert.Equal(t, []Role{RoleHost}, got[0].Roles)
+		assert.Equal(t, uint(2), got[1].Run.ID)
+		assert.Equal(t, []Role{RoleHost, RoleCommentator}, got[1].Roles)
+		assert.Equal(t, uint(3), got[2].Run.ID)
+		assert.Equal(t, []Role{RoleRunner}, got[2].Roles)
+
+		assert.Zero(t, s.ForTalent("nobody"))
+		assert.Zero(t, s.ForTalent(" "))
+		assert.Zero(t, (*Schedule)(nil).ForTalent("kungfu"))
+		assert.Equal(t, "commentator", RoleCommentator.String())
+	})
+	t.Run("Role JSON", func(t *testing.T) {
+		data, err := json.Marshal([]Role{RoleHost, RoleCommentator})
+		assert.NoError(t, err)
+		assert.Equal(t, `["host","commentator"]`, string(data))
+
+		var roles []Role
+		assert.NoError(t, json.Unmarshal(data, &roles))
+		assert.Equal(t, []Role{RoleHost, RoleCommentator}, roles)
+
+		assert.Error(t, json.Unmarshal([]byte(`["speedrunner"]`), &roles))
+		_, err = json.Marshal(Role(0))
+		assert.Error(t, err)
+	})
+}
@@ -1,5 +1,29 @@
 package gdq
 
+import (
+	"fmt"
+)
+
+// Talent represents a runner, host or commentator at a GDQ.
+//
+// Stream is a link to where they stream, and Platform the streaming
+// platform that link is on. Twitter and YouTube are handles, not links. Any
+// of these can be empty.
 type Talent struct {
-	Name string `json:"name"`
+	ID       uint   `json:"id"`
+	Name     string `json:"name"`
+	Pronouns string `json:"pronouns"`
+	Stream   string `json:"stream"`
+	Platform string `json:"platform"`
+	Twitter  string `json:"twitter"`
+	YouTube  string `json:"youtube"`
+}
+
+// String returns the name of the talent, followed by their pronouns if they
+// have provided any.
+func (t Talent) String() string {
+	if t.Pronouns == "" {
+		return t.Name
+	}
+	return fmt.Sprintf("%s (%s)", t.Name, t.Pronouns)
 }
@@ -0,0 +1,144 @@
+package gdq
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// ItemKind is the kind of segment an [Item] in a [Timeline] represents.
+type ItemKind int
+
+const (
+	KindRun ItemKind = iota + 1
+	KindInterview
+	KindAd
+)
+
+func (k ItemKind) String() string {
+	switch k {
+	case KindRun:
+		return "run"
+	case KindInterview:
+		return "interview"
+	case KindAd:
+		return "ad"
+	default:
+		return fmt.Sprintf("ItemKind(%d)", int(k))
+	}
+}
+
+// Item is a single segment in a [Timeline].
+//
+// Depending on the Kind, exactly one of Run, Interview or Ad is set.
+type Item struct {
+	Kind      ItemKind   `json:"kind"`
+	Start     time.Time  `json:"start"`
+	Length    Duration   `json:"length"`
+	Order     int        `json:"order"`
+	Suborder  int        `json:"suborder"`
+	Run       *Run       `json:"run,omitempty"`
+	Interview *Interview `json:"interview,omitempty"`
+	Ad        *Ad        `json:"ad,omitempty"`
+}
+
+// Timeline represents everything that airs at a GDQ event, in order.
+//
+// Contrary to a [Schedule], a timeline also contains interviews and ads.
+type Timeline struct {
+	Items []*Item
+}
+
+// NewTimeline returns a timeline with the runs, interviews and ads ordered
+// the way the tracker orders them.
+//
+// Interviews and ads air after the run with the same order, sorted by their
+// suborder. The tracker only knows when runs start, so the start of every
+// other item is estimated. The first one starts when the run it follows
+// is expected to finish, excluding setup time, and every next one starts
+// when the previous one ends. Items that come before the first run have
+// no start time.
+//
+// You'll get a nil timeline if there is nothing to put on it.
+func NewTimeline(runs []*Run, interviews []*Interview, ads []*Ad) *Timeline {
+	items := make([]*Item, 0, len(runs)+len(interviews)+len(ads))
+	for _, run := range runs {
+		items = append(items, &Item{
+			Kind:   KindRun,
+			Start:  run.Start,
+			Length: run.Estimate,
+			Order:  run.Order,
+			Run:    run,
+		})
+	}
+	for _, iv := range interviews {
+		items = append(items, &Item{
+			Kind:      KindInterview,
+			Length:    iv.Length,
+			Order:     iv.Order,
+			Suborder:  iv.Suborder,
+			Interview: iv,
+		})
+	}
+	for _, ad := range ads {
+		items = append(items, &Item{
+			Kind:     KindAd,
+			Length:   ad.Length,
+			Order:    ad.Order,
+			Suborder: ad.Suborder,
+			Ad:       ad,
+		})
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	slices.SortStableFunc(items, func(a, b *Item) int {
+		return cmp.Or(
+			cmp.Compare(a.Order, b.Order),
+			cmp.Compare(a.Suborder, b.Suborder),
+		)
+	})
+
+	var next time.Time
+	for _, item := range items {
+		if item.Kind == KindRun {
+			next = item.Run.Start.Add(item.Run.RunTime.Duration)
+			if item.Run.RunTime.Duration == 0 {
+				next = item.Run.Start.Add(item.Run.Estimate.Duration)
+			}
+			continue
+		}
+		if next.IsZero() {
+			continue
+		}
+		item.Start = next
+		next = next.Add(item.Length.Duration)
+	}
+
+	return &Timeline{Items: items}
+}
+
+// After returns the items that air after the run and before the next one.
+//
+// You'll get nil if the run isn't on the timeline or nothing airs in
+// between.
+func (t *Timeline) After(run *Run) []*Item {
+	idx := slices.IndexFunc(t.Items, func(item *Item) bool {
+		return item.Kind == KindRun && item.Run == run
+	})
+	if idx == -1 {
+		return nil
+	}
+
+	var items []*Item
+	for _, item := range t.Items[idx+1:] {
+		if item.Kind == KindRun {
+			break
+		}
+		items = append(items, item)
+	}
+	return items
+}
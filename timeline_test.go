@@ -0,0 +1,67 @@
+package gdq
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestNewTimeline(t *testing.T) {
+	start := time.Date(2021, 1, 3, 12, 0, 0, 0, time.UTC)
+	runs := []*Run{
+		{
+			Title:     "Game 2",
+			Start:     start.Add(time.Hour),
+			RunTime:   Duration{50 * time.Minute},
+			SetupTime: Duration{10 * time.Minute},
+			Estimate:  Duration{time.Hour},
+			Order:     2,
+		},
+		{
+			Title:     "Game 1",
+			Start:     start,
+			RunTime:   Duration{50 * time.Minute},
+			SetupTime: Duration{10 * time.Minute},
+			Estimate:  Duration{time.Hour},
+			Order:     1,
+		},
+	}
+	ivs := []*Interview{
+		{Topic: "Second", Order: 1, Suborder: 2, Length: Duration{2 * time.Minute}},
+		{Topic: "Before", Order: 0, Suborder: 1, Length: Duration{2 * time.Minute}},
+	}
+	ads := []*Ad{
+		{Name: "First", Order: 1, Suborder: 1, Length: Duration{3 * time.Minute}},
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		assert.Equal(t, nil, NewTimeline(nil, nil, nil))
+	})
+	t.Run("ordering", func(t *testing.T) {
+		tl := NewTimeline(runs, ivs, ads)
+		assert.Equal(t, 5, len(tl.Items))
+		kinds := []ItemKind{}
+		for _, item := range tl.Items {
+			kinds = append(kinds, item.Kind)
+		}
+		assert.Equal(t, []ItemKind{KindInterview, KindRun, KindAd, KindInterview, KindRun}, kinds)
+	})
+	t.Run("start times", func(t *testing.T) {
+		tl := NewTimeline(runs, ivs, ads)
+		assert.True(t, tl.Items[0].Start.IsZero())
+		assert.Equal(t, start, tl.Items[1].Start)
+		assert.Equal(t, start.Add(50*time.Minute), tl.Items[2].Start)
+		assert.Equal(t, start.Add(53*time.Minute), tl.Items[3].Start)
+		assert.Equal(t, start.Add(time.Hour), tl.Items[4].Start)
+	})
+	t.Run("after", func(t *testing.T) {
+		tl := NewTimeline(runs, ivs, ads)
+		after := tl.After(runs[1])
+		assert.Equal(t, 2, len(after))
+		assert.Equal(t, "First", after[0].Ad.Name)
+		assert.Equal(t, "Second", after[1].Interview.Topic)
+		assert.Equal(t, 0, len(tl.After(runs[0])))
+		assert.Equal(t, 0, len(tl.After(&Run{})))
+	})
+}
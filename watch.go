@@ -0,0 +1,152 @@
+package gdq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ChangeKind is the kind of change a [ScheduleChange] represents.
+type ChangeKind int
+
+const (
+	// RunAdded is a run that's new to the schedule.
+	RunAdded ChangeKind = iota + 1
+	// RunRemoved is a run that's no longer in the schedule.
+	RunRemoved
+	// RunMoved is a change to the start time of a run.
+	RunMoved
+	// RunReestimated is a change to the estimate of a run.
+	RunReestimated
+	// RunTalentChanged is a change to the runners, hosts or commentators of
+	// a run.
+	RunTalentChanged
+	// RunCategoryChanged is a change to the category of a run.
+	RunCategoryChanged
+	// RunLive is sent when the start time of a run has passed.
+	RunLive
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case RunAdded:
+		return "added"
+	case RunRemoved:
+		return "removed"
+	case RunMoved:
+		return "moved"
+	case RunReestimated:
+		return "reestimated"
+	case RunTalentChanged:
+		return "talent changed"
+	case RunCategoryChanged:
+		return "category changed"
+	case RunLive:
+		return "live"
+	default:
+		return fmt.Sprintf("ChangeKind(%d)", int(k))
+	}
+}
+
+// ScheduleChange is a change to a run in a [Schedule].
+//
+// Run is the run as it is now, and Old the run as it was before the
+// change. Old is nil for added runs and runs that went live, Run is nil
+// for removed runs.
+//
+// If retrieving the schedule failed, only Err is set.
+type ScheduleChange struct {
+	Kind ChangeKind
+	Run  *Run
+	Old  *Run
+	Err  error
+}
+
+// minWatchInterval is the shortest interval WatchSchedule polls at.
+const minWatchInterval = time.Second
+
+// WatchSchedule polls the schedule of an event every interval, and sends
+// the changes to it on the returned channel. Intervals shorter than a
+// second are raised to a second.
+//
+// The first poll establishes what the schedule looks like, so changes are
+// only sent from the second poll on. Runs are reported as live by the first
+// poll after their start time has passed. When a poll fails, a change with
+// the error is sent and polling continues. Use a [RetryPolicy] to smooth
+// over transient failures.
+//
+// The channel is closed once ctx is done.
+func (c *Client) WatchSchedule(ctx context.Context, ev uint, interval time.Duration) <-chan ScheduleChange {
+	interval = max(interval, minWatchInterval)
+	ch := make(chan ScheduleChange)
+
+	go func() {
+		defer close(ch)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		c.watch(ctx, ev, ticker.C, time.Now, ch)
+	}()
+
+	return ch
+}
+
+// watch polls the schedule of an event once, and again on every tick,
+// sending the changes on ch. It uses now to tell the time, and returns once
+// ctx is done.
+func (c *Client) watch(ctx context.Context, ev uint, tick <-chan time.Time, now func() time.Time, ch chan<- ScheduleChange) {
+	var (
+		prev   *Schedule
+		polled bool
+		last   = now()
+	)
+	for {
+		var changes []ScheduleChange
+
+		s, err := c.Schedule(ctx, ev)
+		if errors.Is(err, ErrNoRuns) {
+			s, err = nil, nil
+		}
+		t := now()
+
+		switch {
+		case err != nil:
+			if ctx.Err() != nil {
+				return
+			}
+			changes = []ScheduleChange{{Err: err}}
+		case !polled:
+			prev, polled, last = s, true, t
+		default:
+			changes = append(diff(prev, s), wentLive(s, last, t)...)
+			prev, last = s, t
+		}
+
+		for _, change := range changes {
+			select {
+			case ch <- change:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case <-tick:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// wentLive returns a change for every run that started after from, up to
+// and including to.
+func wentLive(s *Schedule, from, to time.Time) []ScheduleChange {
+	var changes []ScheduleChange
+	for _, run := range s.runs() {
+		if run.Start.After(from) && !run.Start.After(to) {
+			changes = append(changes, ScheduleChange{Kind: RunLive, Run: run})
+		}
+	}
+	return changes
+}
@@ -0,0 +1,106 @@
+package gdq
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestWatchSchedule(t *testing.T) {
+	start := testStart
+	live := start.Add(time.Minute)
+	later := start.Add(time.Hour)
+
+	run := func(id uint, start time.Time, estimate string) string {
+		return fmt.Sprintf(`{"id":%d,"name":"run %d","starttime":%q,"run_time":%q,"setup_time":"0:00:00"}`, id, id, start.Format(time.RFC3339Nano), estimate)
+	}
+	polls := [][]string{
+		{run(1, live, "1:00:00"), run(2, later, "1:00:00")},
+		nil, // fails
+		{run(1, live, "1:00:00"), run(2, later, "1:30:00"), run(3, later.Add(90*time.Minute), "0:30:00")},
+	}
+
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(calls.Add(1)) - 1
+		switch {
+		case n == 1:
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		case n >= len(polls):
+			n = len(polls) - 1
+		}
+		fmt.Fprintf(w, `{"count":%d,"next":null,"previous":null,"results":[%s]}`, len(polls[n]), strings.Join(polls[n], ","))
+	}))
+	defer ts.Close()
+
+	var clock atomic.Int64
+	clock.Store(start.UnixNano())
+	now := func() time.Time { return time.Unix(0, clock.Load()).UTC() }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tick := make(chan time.Time)
+	ch := make(chan ScheduleChange)
+	go func() {
+		defer close(ch)
+		New(WithBaseURL(ts.URL)).watch(ctx, 34, tick, now, ch)
+	}()
+
+	timeout := time.After(5 * time.Second)
+	poll := func() {
+		select {
+		case tick <- now():
+		case <-timeout:
+			t.Fatal("timed out waiting to poll")
+		}
+	}
+	recv := func() ScheduleChange {
+		select {
+		case change := <-ch:
+			return change
+		case <-timeout:
+			t.Fatal("timed out waiting for a change")
+		}
+		return ScheduleChange{}
+	}
+
+	poll()
+	assert.IsError(t, recv().Err, ErrServer)
+
+	clock.Store(live.Add(time.Second).UnixNano())
+	poll()
+	var got []string
+	for range 3 {
+		change := recv()
+		assert.NoError(t, change.Err)
+		got = append(got, fmt.Sprintf("%d %s", change.Run.ID, change.Kind))
+	}
+	assert.Equal(t, []string{"2 reestimated", "3 added", "1 live"}, got)
+
+	cancel()
+	for range ch {
+	}
+}
+
+func TestWatchScheduleInterval(t *testing.T) {
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		fmt.Fprint(w, `{"count":0,"next":null,"previous":null,"results":[]}`)
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	for range New(WithBaseURL(ts.URL)).WatchSchedule(ctx, 34, 0) {
+	}
+	assert.Equal(t, 1, calls.Load())
+}